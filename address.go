@@ -0,0 +1,381 @@
+package eversendSdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrInvalidAddress is matched by the error returned for a crypto address that is malformed.
+var ErrInvalidAddress = errors.New("invalid crypto address")
+
+// InvalidAddressError is returned for an address that is not in the format of its chain, e.g with a wrong checksum.
+// The Chain is empty when the chain of the address is not known.
+type InvalidAddressError struct {
+	Address string
+	Chain   string
+	Reason  string
+}
+
+func (e *InvalidAddressError) Error() string {
+	if e.Chain == "" {
+		return fmt.Sprintf("%s %q: %s", ErrInvalidAddress.Error(), e.Address, e.Reason)
+	}
+
+	return fmt.Sprintf("%s %q for %s: %s", ErrInvalidAddress.Error(), e.Address, e.Chain, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidAddress.
+func (e *InvalidAddressError) Is(target error) bool {
+	return target == ErrInvalidAddress
+}
+
+// AddressValidator checks the format of an address of a chain offline. It returns the reason the address is invalid,
+// or "" if it is valid.
+type AddressValidator func(address string) string
+
+var addressValidatorsMutex = &sync.RWMutex{}
+
+// addressValidators are keyed by the Chain of CryptoChain e.g "TRON".
+var addressValidators = map[string]AddressValidator{
+	"TRON": validateTronAddress,
+	"ETH":  validateEVMAddress,
+	"BSC":  validateEVMAddress,
+	"BTC":  validateBitcoinAddress,
+	"SOL":  validateSolanaAddress,
+}
+
+// RegisterAddressValidator sets the validator of the addresses of a chain, replacing the built in one if any.
+func RegisterAddressValidator(chain string, validator AddressValidator) {
+	addressValidatorsMutex.Lock()
+	defer addressValidatorsMutex.Unlock()
+
+	addressValidators[strings.ToUpper(chain)] = validator
+}
+
+// ValidateAddress checks offline that the address is in the format of the chain, as returned by AssetChains:
+//
+//   - TRON: base58check with the 0x41 prefix, e.g "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+//   - ETH and BSC: 0x and 40 hex digits, with a valid EIP-55 checksum if it has mixed case,
+//   - BTC: base58check P2PKH or P2SH, or bech32/bech32m segwit, mainnet or testnet,
+//   - SOL: base58 of a 32 byte public key.
+//
+// An *InvalidAddressError is returned for a malformed address. Addresses of chains without a validator are accepted.
+func ValidateAddress(chain string, address string) error {
+	addressValidatorsMutex.RLock()
+	validator, ok := addressValidators[strings.ToUpper(chain)]
+	addressValidatorsMutex.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	if reason := validator(address); reason != "" {
+		return &InvalidAddressError{Address: address, Chain: chain, Reason: reason}
+	}
+
+	return nil
+}
+
+// ValidateAddress checks offline that the address is in the format of the chain, see ValidateAddress.
+func (c CryptoChain) ValidateAddress(address string) error {
+	return ValidateAddress(c.Chain, address)
+}
+
+// validateAnyAddress checks that the address is in the format of at least one chain, when its chain is not known.
+func validateAnyAddress(address string) error {
+	if address == "" {
+		return &InvalidAddressError{Address: address, Reason: "is empty"}
+	}
+
+	addressValidatorsMutex.RLock()
+	defer addressValidatorsMutex.RUnlock()
+
+	for _, validator := range addressValidators {
+		if validator(address) == "" {
+			return nil
+		}
+	}
+
+	return &InvalidAddressError{Address: address, Reason: "is not the address of a supported chain"}
+}
+
+func validateTronAddress(address string) string {
+	payload, reason := decodeBase58Check(address)
+
+	if reason != "" {
+		return reason
+	}
+
+	if len(payload) != 21 || payload[0] != 0x41 {
+		return "is not a TRON address"
+	}
+
+	return ""
+}
+
+func validateEVMAddress(address string) string {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return "must be 0x followed by 40 hex digits"
+	}
+
+	digits := address[2:]
+
+	if _, err := hex.DecodeString(digits); err != nil {
+		return "must be 0x followed by 40 hex digits"
+	}
+
+	// an address in a single case has no checksum
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return ""
+	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(strings.ToLower(digits)))
+	sum := hex.EncodeToString(hash.Sum(nil))
+
+	for i, c := range digits {
+		if c >= '0' && c <= '9' {
+			continue
+		}
+
+		// EIP-55: a letter is upper case when the nibble of the hash at its position is 8 or more
+		if (sum[i] >= '8') != (c >= 'A' && c <= 'F') {
+			return "has an invalid EIP-55 checksum"
+		}
+	}
+
+	return ""
+}
+
+func validateBitcoinAddress(address string) string {
+	lower := strings.ToLower(address)
+
+	if strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") {
+		return validateSegwitAddress(address)
+	}
+
+	payload, reason := decodeBase58Check(address)
+
+	if reason != "" {
+		return reason
+	}
+
+	if len(payload) != 21 {
+		return "is not a Bitcoin address"
+	}
+
+	switch payload[0] {
+	case 0x00, 0x05, 0x6f, 0xc4:
+		// P2PKH and P2SH on mainnet and testnet
+		return ""
+	default:
+		return "is not a Bitcoin address"
+	}
+}
+
+func validateSolanaAddress(address string) string {
+	key, ok := decodeBase58(address)
+
+	if !ok {
+		return "is not base58"
+	}
+
+	if len(key) != 32 {
+		return "is not a 32 byte Solana public key"
+	}
+
+	return ""
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a base58 string, keeping its leading zero bytes.
+func decodeBase58(encoded string) ([]byte, bool) {
+	if encoded == "" {
+		return nil, false
+	}
+
+	decoded := []byte{}
+
+	for _, c := range encoded {
+		digit := strings.IndexRune(base58Alphabet, c)
+
+		if digit < 0 {
+			return nil, false
+		}
+
+		carry := digit
+
+		// decoded is little endian while it is built
+		for i := range decoded {
+			carry += int(decoded[i]) * 58
+			decoded[i] = byte(carry)
+			carry >>= 8
+		}
+
+		for carry > 0 {
+			decoded = append(decoded, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	for _, c := range encoded {
+		if c != '1' {
+			break
+		}
+
+		decoded = append(decoded, 0)
+	}
+
+	for i, j := 0, len(decoded)-1; i < j; i, j = i+1, j-1 {
+		decoded[i], decoded[j] = decoded[j], decoded[i]
+	}
+
+	return decoded, true
+}
+
+// decodeBase58Check decodes a base58 string ending with the first 4 bytes of the double SHA-256 of its payload.
+func decodeBase58Check(encoded string) ([]byte, string) {
+	decoded, ok := decodeBase58(encoded)
+
+	if !ok {
+		return nil, "is not base58"
+	}
+
+	if len(decoded) < 5 {
+		return nil, "is too short"
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+
+	if !bytes.Equal(second[:4], checksum) {
+		return nil, "has an invalid checksum"
+	}
+
+	return payload, ""
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	bech32Constant  = 1
+	bech32mConstant = 0x2bc830a3
+)
+
+// validateSegwitAddress checks a BIP-173 or BIP-350 segwit address.
+func validateSegwitAddress(address string) string {
+	if address != strings.ToLower(address) && address != strings.ToUpper(address) {
+		return "mixes upper and lower case"
+	}
+
+	address = strings.ToLower(address)
+	separator := strings.LastIndexByte(address, '1')
+
+	// the data part needs at least the witness version and the 6 character checksum
+	if len(address) > 90 || separator < 1 || separator+8 > len(address) {
+		return "is not a bech32 address"
+	}
+
+	hrp := address[:separator]
+
+	if hrp != "bc" && hrp != "tb" {
+		return "is not a Bitcoin address"
+	}
+
+	data := make([]byte, 0, len(address)-separator-1)
+
+	for _, c := range address[separator+1:] {
+		value := strings.IndexRune(bech32Charset, c)
+
+		if value < 0 {
+			return "is not a bech32 address"
+		}
+
+		data = append(data, byte(value))
+	}
+
+	version := data[0]
+	constant := bech32Polymod(append(bech32ExpandHRP(hrp), data...))
+
+	if (version == 0 && constant != bech32Constant) || (version != 0 && constant != bech32mConstant) {
+		return "has an invalid checksum"
+	}
+
+	program, ok := convertBits(data[1:len(data)-6], 5, 8)
+
+	if !ok || version > 16 || len(program) < 2 || len(program) > 40 {
+		return "is not a segwit address"
+	}
+
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "is not a segwit address"
+	}
+
+	return ""
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	checksum := uint32(1)
+
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+
+	return checksum
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+
+	for _, c := range hrp {
+		expanded = append(expanded, byte(c>>5))
+	}
+
+	expanded = append(expanded, 0)
+
+	for _, c := range hrp {
+		expanded = append(expanded, byte(c&31))
+	}
+
+	return expanded
+}
+
+// convertBits regroups 5 bit values into bytes, rejecting non-zero padding.
+func convertBits(data []byte, from uint, to uint) ([]byte, bool) {
+	accumulator, bits := 0, uint(0)
+	converted := []byte{}
+	max := 1<<to - 1
+
+	for _, value := range data {
+		accumulator = accumulator<<from | int(value)
+		bits += from
+
+		for bits >= to {
+			bits -= to
+			converted = append(converted, byte(accumulator>>bits&max))
+		}
+	}
+
+	if bits >= from || accumulator<<(to-bits)&max != 0 {
+		return nil, false
+	}
+
+	return converted, true
+}
@@ -0,0 +1,93 @@
+package eversendSdk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestValidateAddress(t *testing.T) {
+	for _, tc := range []struct {
+		chain   string
+		address string
+		valid   bool
+	}{
+		{"TRON", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", true},
+		{"TRON", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", false},
+		{"TRON", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", false},
+		{"ETH", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{"ETH", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", true},
+		{"ETH", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true},
+		{"ETH", "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", true},
+		{"BSC", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", false},
+		{"ETH", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", false},
+		{"ETH", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00", false},
+		{"BTC", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", true},
+		{"BTC", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", true},
+		{"BTC", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", false},
+		{"BTC", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", true},
+		{"BTC", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", true},
+		{"BTC", "bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", true},
+		{"BTC", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdr", false},
+		{"BTC", "bc1qW508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", false},
+		// valid bech32m checksums over empty data
+		{"BTC", "bc1a8xfp7", false},
+		{"BTC", "tb1dclvmr", false},
+		{"BTC", "ltc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", false},
+		{"SOL", "So11111111111111111111111111111111111111112", true},
+		{"SOL", "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", true},
+		{"SOL", "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xW", false},
+		{"SOL", "0Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", false},
+		{"XRP", "anything", true},
+	} {
+		err := ValidateAddress(tc.chain, tc.address)
+
+		if tc.valid && err != nil {
+			t.Errorf("%s %s: got %v, want valid", tc.chain, tc.address, err)
+		}
+
+		if !tc.valid && !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("%s %s: got %v, want ErrInvalidAddress", tc.chain, tc.address, err)
+		}
+	}
+}
+
+func TestValidateAddressOfCreatedAddresses(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	for _, coin := range []string{"USDT", "USDC", "BTC"} {
+		asset, err := app.Crypto.AssetChains(coin)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, chain := range asset.Chains {
+			address, err := app.Crypto.CreateAddress(chain.AssetID, "Jane Doe", "jane@example.com", "deposits")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := chain.ValidateAddress(address.Address); err != nil {
+				t.Errorf("%s: %v", chain.AssetID, err)
+			}
+		}
+	}
+}
+
+func TestAddressTransactionsRejectsMalformedAddresses(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	for _, address := range []string{"", "not-an-address", "0x1234", "../wallets", "bc1a8xfp7"} {
+		if _, err := app.Crypto.AddressTransactions(address); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("%q: got %v, want ErrInvalidAddress", address, err)
+		}
+	}
+
+	for _, req := range srv.Requests() {
+		if req.Method == http.MethodGet && req.Path != "auth/token" {
+			t.Fatalf("got a request to %s for a malformed address", req.Path)
+		}
+	}
+}
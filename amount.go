@@ -0,0 +1,29 @@
+package eversendSdk
+
+import "strconv"
+
+// ParseAmount function to read an amount the API sent as a JSON number or as a string e.g "1000.50".
+// It returns false for a missing or malformed amount.
+func ParseAmount(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		amount, err := strconv.ParseFloat(v, 64)
+		return amount, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// WalletBalance function to get the balance of a wallet returned by Wallet.List or Wallet.Find.
+// It returns false if the wallet has no balance.
+func WalletBalance(wallet map[string]interface{}) (float64, bool) {
+	for _, key := range []string{"amount", "balance"} {
+		if balance, ok := ParseAmount(wallet[key]); ok {
+			return balance, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,272 @@
+package eversendSdk
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BalanceEventType is the kind of a BalanceEvent.
+type BalanceEventType string
+
+const (
+	// BalanceLow is emitted when a balance falls below its low threshold.
+	BalanceLow BalanceEventType = "low"
+	// BalanceHigh is emitted when a balance rises above its high threshold.
+	BalanceHigh BalanceEventType = "high"
+	// BalanceNormal is emitted when a balance that was low or high is back between its thresholds.
+	BalanceNormal BalanceEventType = "normal"
+	// BalanceChanged is emitted when a balance changed by more than its maximum change between two polls.
+	BalanceChanged BalanceEventType = "changed"
+)
+
+// BalanceEvent is a change of a wallet balance watched by a BalanceMonitor.
+// The Threshold is the threshold or maximum change that was crossed. Previous is 0 on the first poll.
+type BalanceEvent struct {
+	Type      BalanceEventType `json:"type"`
+	Currency  string           `json:"currency"`
+	Balance   float64          `json:"balance"`
+	Previous  float64          `json:"previous"`
+	Threshold float64          `json:"threshold"`
+	At        time.Time        `json:"at"`
+}
+
+// BalanceSnapshot is the result of the last poll of a BalanceMonitor, for health checks.
+// UpdatedAt is the time of the last successful poll. LastError is the error of the last poll if it failed.
+// Low and High are the currencies whose balance is below or above its threshold.
+type BalanceSnapshot struct {
+	Balances  map[string]float64 `json:"balances"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+	LastError string             `json:"lastError,omitempty"`
+	Low       []string           `json:"low"`
+	High      []string           `json:"high"`
+}
+
+// BalanceMonitor polls the wallet balances with Wallet.List and emits events when they cross their thresholds.
+type BalanceMonitor struct {
+	wallets  WalletService
+	interval time.Duration
+	low      map[string]float64
+	high     map[string]float64
+	change   map[string]float64
+	events   chan BalanceEvent
+	now      func() time.Time
+
+	mutex    sync.RWMutex
+	snapshot BalanceSnapshot
+	// levels is BalanceLow, BalanceHigh or BalanceNormal for each currency seen
+	levels map[string]BalanceEventType
+}
+
+// BalanceMonitorOption is an optional setting passed to NewBalanceMonitor.
+type BalanceMonitorOption func(m *BalanceMonitor)
+
+// WithBalanceInterval sets how often the balances are polled. The default is one minute.
+func WithBalanceInterval(interval time.Duration) BalanceMonitorOption {
+	return func(m *BalanceMonitor) {
+		m.interval = interval
+	}
+}
+
+// WithLowBalance sets the balance of a wallet e.g "UGX" under which a BalanceLow event is emitted.
+func WithLowBalance(currency string, amount float64) BalanceMonitorOption {
+	return func(m *BalanceMonitor) {
+		m.low[strings.ToUpper(currency)] = amount
+	}
+}
+
+// WithHighBalance sets the balance of a wallet above which a BalanceHigh event is emitted, e.g to sweep funds.
+func WithHighBalance(currency string, amount float64) BalanceMonitorOption {
+	return func(m *BalanceMonitor) {
+		m.high[strings.ToUpper(currency)] = amount
+	}
+}
+
+// WithMaxBalanceChange sets the largest change of a wallet balance expected between two polls.
+// A BalanceChanged event is emitted for a larger change, up or down.
+func WithMaxBalanceChange(currency string, amount float64) BalanceMonitorOption {
+	return func(m *BalanceMonitor) {
+		m.change[strings.ToUpper(currency)] = amount
+	}
+}
+
+// NewBalanceMonitor function to create a BalanceMonitor polling the WalletService e.g app.Wallets.
+// The opts are optional settings e.g WithLowBalance.
+func NewBalanceMonitor(wallets WalletService, opts ...BalanceMonitorOption) *BalanceMonitor {
+	m := &BalanceMonitor{
+		wallets:  wallets,
+		interval: time.Minute,
+		low:      map[string]float64{},
+		high:     map[string]float64{},
+		change:   map[string]float64{},
+		events:   make(chan BalanceEvent, 64),
+		now:      time.Now,
+		levels:   map[string]BalanceEventType{},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Events returns the channel the events are delivered on. It must be read, as Poll waits for room on the channel.
+// It is never closed.
+func (m *BalanceMonitor) Events() <-chan BalanceEvent {
+	return m.events
+}
+
+// Snapshot returns the balances of the last successful poll and the outcome of the last poll.
+func (m *BalanceMonitor) Snapshot() BalanceSnapshot {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	snapshot := m.snapshot
+	snapshot.Balances = make(map[string]float64, len(m.snapshot.Balances))
+
+	for currency, balance := range m.snapshot.Balances {
+		snapshot.Balances[currency] = balance
+	}
+
+	snapshot.Low = append([]string{}, m.snapshot.Low...)
+	snapshot.High = append([]string{}, m.snapshot.High...)
+
+	return snapshot
+}
+
+// Run polls the balances every interval until the context is done, and returns its error.
+// The errors of the polls are recorded in the snapshot.
+func (m *BalanceMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Poll(ctx); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll polls the balances once, updates the snapshot and delivers the events.
+func (m *BalanceMonitor) Poll(ctx context.Context) error {
+	wallets, err := m.wallets.ListContext(ctx)
+
+	if err != nil {
+		m.mutex.Lock()
+		m.snapshot.LastError = err.Error()
+		m.mutex.Unlock()
+
+		return err
+	}
+
+	balances := walletBalances(wallets)
+	now := m.now()
+
+	m.mutex.Lock()
+
+	previous := m.snapshot.Balances
+	events := []BalanceEvent{}
+
+	for _, currency := range sortedCurrencies(balances) {
+		balance := balances[currency]
+		last, seen := previous[currency]
+		event := BalanceEvent{Currency: currency, Balance: balance, Previous: last, At: now}
+
+		if limit, ok := m.change[currency]; ok && seen && math.Abs(balance-last) > limit {
+			event.Type, event.Threshold = BalanceChanged, limit
+			events = append(events, event)
+		}
+
+		level, threshold := m.level(currency, balance)
+
+		if level != m.levels[currency] && (level != BalanceNormal || seen) {
+			event.Type, event.Threshold = level, threshold
+			events = append(events, event)
+		}
+
+		m.levels[currency] = level
+	}
+
+	m.snapshot = BalanceSnapshot{Balances: balances, UpdatedAt: now, Low: []string{}, High: []string{}}
+
+	for _, currency := range sortedCurrencies(balances) {
+		switch m.levels[currency] {
+		case BalanceLow:
+			m.snapshot.Low = append(m.snapshot.Low, currency)
+		case BalanceHigh:
+			m.snapshot.High = append(m.snapshot.High, currency)
+		}
+	}
+
+	m.mutex.Unlock()
+
+	for _, event := range events {
+		select {
+		case m.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// level returns whether the balance is low, high or normal, and the threshold it crossed.
+func (m *BalanceMonitor) level(currency string, balance float64) (BalanceEventType, float64) {
+	if low, ok := m.low[currency]; ok && balance < low {
+		return BalanceLow, low
+	}
+
+	if high, ok := m.high[currency]; ok && balance > high {
+		return BalanceHigh, high
+	}
+
+	return BalanceNormal, 0
+}
+
+// walletBalances returns the balance of each wallet returned by Wallet.List, by currency.
+func walletBalances(wallets []interface{}) map[string]float64 {
+	balances := map[string]float64{}
+
+	for _, item := range wallets {
+		wallet, ok := item.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		currency, _ := wallet["currency"].(string)
+
+		if currency == "" {
+			continue
+		}
+
+		if balance, ok := WalletBalance(wallet); ok {
+			balances[strings.ToUpper(currency)] = balance
+		}
+	}
+
+	return balances
+}
+
+func sortedCurrencies(balances map[string]float64) []string {
+	currencies := make([]string, 0, len(balances))
+
+	for currency := range balances {
+		currencies = append(currencies, currency)
+	}
+
+	sort.Strings(currencies)
+
+	return currencies
+}
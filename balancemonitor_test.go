@@ -0,0 +1,103 @@
+package eversendSdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func pollBalances(t *testing.T, m *BalanceMonitor) []BalanceEvent {
+	t.Helper()
+
+	if err := m.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	events := []BalanceEvent{}
+
+	for {
+		select {
+		case event := <-m.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestBalanceMonitorThresholds(t *testing.T) {
+	app, srv := newTestApp(t)
+	srv.SetBalance("UGX", 40000)
+
+	monitor := NewBalanceMonitor(app.Wallets,
+		WithLowBalance("ugx", 50000),
+		WithHighBalance("KES", 500000),
+		WithMaxBalanceChange("USD", 100))
+
+	// a balance already low is reported on the first poll
+	events := pollBalances(t, monitor)
+
+	if len(events) != 1 || events[0].Type != BalanceLow || events[0].Currency != "UGX" || events[0].Threshold != 50000 {
+		t.Fatalf("got %+v, want UGX low", events)
+	}
+
+	if events := pollBalances(t, monitor); len(events) != 0 {
+		t.Fatalf("got %+v while UGX stays low", events)
+	}
+
+	srv.SetBalance("UGX", 60000)
+	srv.SetBalance("KES", 600000)
+	srv.SetBalance("USD", 850)
+
+	events = pollBalances(t, monitor)
+
+	if len(events) != 3 {
+		t.Fatalf("got %+v, want KES high, UGX normal and USD changed", events)
+	}
+
+	for i, want := range []BalanceEvent{
+		{Type: BalanceHigh, Currency: "KES", Balance: 600000, Previous: 100000, Threshold: 500000},
+		{Type: BalanceNormal, Currency: "UGX", Balance: 60000, Previous: 40000},
+		{Type: BalanceChanged, Currency: "USD", Balance: 850, Previous: 1000, Threshold: 100},
+	} {
+		want.At = events[i].At
+
+		if events[i] != want {
+			t.Errorf("got %+v, want %+v", events[i], want)
+		}
+	}
+
+	snapshot := monitor.Snapshot()
+
+	if snapshot.Balances["UGX"] != 60000 || len(snapshot.Low) != 0 || len(snapshot.High) != 1 || snapshot.UpdatedAt.IsZero() {
+		t.Fatalf("got snapshot %+v", snapshot)
+	}
+}
+
+func TestBalanceMonitorSnapshotKeepsLastBalances(t *testing.T) {
+	app, srv := newTestApp(t)
+	monitor := NewBalanceMonitor(app.Wallets, WithLowBalance("UGX", 2000000))
+
+	pollBalances(t, monitor)
+	updated := monitor.Snapshot().UpdatedAt
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultServerError, Path: "wallets", Times: 1})
+
+	if err := monitor.Poll(context.Background()); err == nil {
+		t.Fatal("got no error from a failed poll")
+	}
+
+	snapshot := monitor.Snapshot()
+
+	if snapshot.LastError == "" || snapshot.UpdatedAt != updated || snapshot.Balances["UGX"] != 1000000 ||
+		len(snapshot.Low) != 1 || snapshot.Low[0] != "UGX" {
+		t.Fatalf("got snapshot %+v after a failed poll", snapshot)
+	}
+
+	pollBalances(t, monitor)
+
+	if monitor.Snapshot().LastError != "" {
+		t.Fatal("the error of a failed poll is kept after a successful one")
+	}
+}
@@ -0,0 +1,218 @@
+package eversendSdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrDuplicateBeneficiary is matched by the error returned when creating a beneficiary that already exists.
+var ErrDuplicateBeneficiary = errors.New("beneficiary already exists")
+
+// DuplicateBeneficiaryError is returned by CreateMomoBeneficiary and CreateBankBeneficiary of an app created with
+// WithDuplicateBeneficiaryCheck when a matching beneficiary is already saved. The beneficiary is not created again. Existing holds the saved beneficiary.
+type DuplicateBeneficiaryError struct {
+	Existing BeneficiaryDetails
+}
+
+func (e *DuplicateBeneficiaryError) Error() string {
+	return fmt.Sprintf("%s: id %s", ErrDuplicateBeneficiary.Error(), e.Existing.ID.String())
+}
+
+// Is reports whether target is ErrDuplicateBeneficiary.
+func (e *DuplicateBeneficiaryError) Is(target error) bool {
+	return target == ErrDuplicateBeneficiary
+}
+
+// BeneficiaryDetails holds the details of a saved beneficiary.
+type BeneficiaryDetails struct {
+	ID                json.Number `json:"id"`
+	FirstName         string      `json:"firstName"`
+	LastName          string      `json:"lastName"`
+	Email             string      `json:"email"`
+	PhoneNumber       string      `json:"phoneNumber"`
+	Country           string      `json:"country"`
+	BankName          string      `json:"bankName"`
+	BankCode          string      `json:"bankCode"`
+	BankAccountName   string      `json:"bankAccountName"`
+	BankAccountNumber string      `json:"bankAccountNumber"`
+	IsBank            bool        `json:"isBank"`
+	IsMomo            bool        `json:"isMomo"`
+}
+
+// BeneficiaryUpdate holds the beneficiary fields to change. Empty fields are left unchanged.
+type BeneficiaryUpdate struct {
+	FirstName         string `json:"firstName,omitempty"`
+	LastName          string `json:"lastName,omitempty"`
+	Email             string `json:"email,omitempty"`
+	PhoneNumber       string `json:"phoneNumber,omitempty"`
+	Country           string `json:"country,omitempty"`
+	BankName          string `json:"bankName,omitempty"`
+	BankCode          string `json:"bankCode,omitempty"`
+	BankAccountName   string `json:"bankAccountName,omitempty"`
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+}
+
+// BeneficiarySearch holds the criteria used by Search. Empty fields are ignored and a beneficiary must match all the others.
+// The Name is matched case-insensitively against the first and last name.
+// The PhoneNumber is matched ignoring spaces, dashes and a leading "+".
+// The Country is the Alpha-2 country code e.g "UG".
+type BeneficiarySearch struct {
+	Name              string
+	PhoneNumber       string
+	BankAccountNumber string
+	Country           string
+}
+
+// Update function to change the details of a saved beneficiary.
+func (e *Beneficiary) Update(beneficiaryId string, update BeneficiaryUpdate) (*BeneficiaryDetails, error) {
+	return e.UpdateContext(context.Background(), beneficiaryId, update)
+}
+
+// UpdateContext is like Update but sends the requests with ctx.
+func (e *Beneficiary) UpdateContext(ctx context.Context, beneficiaryId string, update BeneficiaryUpdate) (*BeneficiaryDetails, error) {
+	var responseData struct {
+		Data BeneficiaryDetails `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "beneficiaries.update", http.MethodPatch, "beneficiaries/"+beneficiaryId, update, &responseData); err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
+
+// Delete function to remove a saved beneficiary.
+func (e *Beneficiary) Delete(beneficiaryId string) error {
+	return e.DeleteContext(context.Background(), beneficiaryId)
+}
+
+// DeleteContext is like Delete but sends the requests with ctx.
+func (e *Beneficiary) DeleteContext(ctx context.Context, beneficiaryId string) error {
+	return e.client().call(ctx, "beneficiaries.delete", http.MethodDelete, "beneficiaries/"+beneficiaryId, nil, nil)
+}
+
+// Search function to find saved beneficiaries matching the given criteria.
+func (e *Beneficiary) Search(query BeneficiarySearch) ([]BeneficiaryDetails, error) {
+	return e.SearchContext(context.Background(), query)
+}
+
+// SearchContext is like Search but sends the requests with ctx.
+func (e *Beneficiary) SearchContext(ctx context.Context, query BeneficiarySearch) ([]BeneficiaryDetails, error) {
+	beneficiaries, err := e.list(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []BeneficiaryDetails{}
+
+	for _, beneficiary := range beneficiaries {
+		if query.matches(beneficiary) {
+			matches = append(matches, beneficiary)
+		}
+	}
+
+	return matches, nil
+}
+
+func (q BeneficiarySearch) matches(b BeneficiaryDetails) bool {
+	if q.Name != "" {
+		fullName := strings.ToLower(b.FirstName + " " + b.LastName)
+
+		if !strings.Contains(fullName, strings.ToLower(strings.TrimSpace(q.Name))) {
+			return false
+		}
+	}
+
+	if q.PhoneNumber != "" && normalizePhoneNumber(q.PhoneNumber) != normalizePhoneNumber(b.PhoneNumber) {
+		return false
+	}
+
+	if q.BankAccountNumber != "" && strings.TrimSpace(q.BankAccountNumber) != strings.TrimSpace(b.BankAccountNumber) {
+		return false
+	}
+
+	if q.Country != "" && !strings.EqualFold(q.Country, b.Country) {
+		return false
+	}
+
+	return true
+}
+
+func normalizePhoneNumber(phoneNumber string) string {
+	return strings.NewReplacer(" ", "", "-", "", "+", "").Replace(phoneNumber)
+}
+
+// FindDuplicate function to get the saved beneficiary with the same account as candidate, or nil if there is none.
+// A momo beneficiary matches on its country and phone number, a bank beneficiary on its country, bank code and
+// account number. It is used to warn before creating a beneficiary that already exists.
+func (e *Beneficiary) FindDuplicate(candidate BeneficiaryDetails) (*BeneficiaryDetails, error) {
+	return e.FindDuplicateContext(context.Background(), candidate)
+}
+
+// FindDuplicateContext is like FindDuplicate but sends the requests with ctx.
+func (e *Beneficiary) FindDuplicateContext(ctx context.Context, candidate BeneficiaryDetails) (*BeneficiaryDetails, error) {
+	beneficiaries, err := e.list(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, beneficiary := range beneficiaries {
+		if !strings.EqualFold(beneficiary.Country, candidate.Country) {
+			continue
+		}
+
+		if candidate.IsBank {
+			if beneficiary.IsBank && beneficiary.BankCode == candidate.BankCode &&
+				strings.TrimSpace(beneficiary.BankAccountNumber) == strings.TrimSpace(candidate.BankAccountNumber) {
+				return &beneficiary, nil
+			}
+
+			continue
+		}
+
+		if beneficiary.IsMomo && normalizePhoneNumber(beneficiary.PhoneNumber) == normalizePhoneNumber(candidate.PhoneNumber) {
+			return &beneficiary, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkDuplicate returns a *DuplicateBeneficiaryError if the app checks for duplicates and candidate is already saved.
+func (e *Beneficiary) checkDuplicate(ctx context.Context, candidate BeneficiaryDetails) error {
+	if !e.client().duplicates {
+		return nil
+	}
+
+	existing, err := e.FindDuplicateContext(ctx, candidate)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return &DuplicateBeneficiaryError{Existing: *existing}
+	}
+
+	return nil
+}
+
+func (e *Beneficiary) list(ctx context.Context) ([]BeneficiaryDetails, error) {
+	var responseData struct {
+		Data struct {
+			Beneficiaries []BeneficiaryDetails `json:"beneficiaries"`
+		} `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "beneficiaries.list", http.MethodGet, "beneficiaries", nil, &responseData); err != nil {
+		return nil, err
+	}
+
+	return responseData.Data.Beneficiaries, nil
+}
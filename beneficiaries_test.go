@@ -0,0 +1,197 @@
+package eversendSdk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func TestCreateAndFindBeneficiaries(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	momo, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if momo.ID == "" || !momo.IsMomo || momo.IsBank {
+		t.Fatalf("got momo beneficiary %+v", momo)
+	}
+
+	bank, err := app.Beneficiaries.CreateBankBeneficiary("John", "Doe", "UG", "Stanbic", "John Doe", "SBICUGKX", "0123456789")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bank.ID == "" || !bank.IsBank || bank.IsMomo {
+		t.Fatalf("got bank beneficiary %+v", bank)
+	}
+
+	list, err := app.Beneficiaries.List()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("got %d beneficiaries, want 2", len(list))
+	}
+
+	found, err := app.Beneficiaries.Find(bank.ID.String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found["bankAccountNumber"] != "0123456789" {
+		t.Fatalf("got beneficiary %v", found)
+	}
+}
+
+func TestCreateDuplicateBeneficiary(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithDuplicateBeneficiaryCheck())
+
+	momo, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256 712-345-678")
+
+	var duplicate *DuplicateBeneficiaryError
+
+	if !errors.Is(err, ErrDuplicateBeneficiary) || !errors.As(err, &duplicate) || duplicate.Existing.ID != momo.ID {
+		t.Fatalf("got error %v, want a duplicate of %s", err, momo.ID)
+	}
+
+	if _, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "KE", "+256712345678"); err != nil {
+		t.Fatalf("got error %v for the same number in another country", err)
+	}
+
+	if _, err := app.Beneficiaries.CreateBankBeneficiary("Jane", "Doe", "UG", "Stanbic", "Jane Doe", "SBICUGKX", "111"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Beneficiaries.CreateBankBeneficiary("Jane", "Doe", "UG", "Stanbic", "Jane Doe", "SBICUGKX", "111")
+
+	if !errors.Is(err, ErrDuplicateBeneficiary) {
+		t.Fatalf("got error %v, want a duplicate bank beneficiary", err)
+	}
+}
+
+func TestFindDuplicateBeneficiary(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	momo, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without WithDuplicateBeneficiaryCheck the same beneficiary is created again, without listing the saved ones
+	if _, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678"); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := srv.RequestCount(http.MethodGet, "beneficiaries"); count != 0 {
+		t.Fatalf("got %d beneficiary lists, want 0", count)
+	}
+
+	existing, err := app.Beneficiaries.FindDuplicate(BeneficiaryDetails{Country: "ug", PhoneNumber: "256712345678", IsMomo: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if existing == nil || existing.ID != momo.ID {
+		t.Fatalf("got %+v, want the beneficiary %s", existing, momo.ID)
+	}
+
+	existing, err = app.Beneficiaries.FindDuplicate(BeneficiaryDetails{Country: "UG", BankCode: "SBICUGKX", BankAccountNumber: "111", IsBank: true})
+
+	if err != nil || existing != nil {
+		t.Fatalf("got %+v and %v, want no duplicate bank beneficiary", existing, err)
+	}
+}
+
+func TestUpdateAndDeleteBeneficiary(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	momo, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := app.Beneficiaries.Update(momo.ID.String(), BeneficiaryUpdate{LastName: "Roe"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.FirstName != "Jane" || updated.LastName != "Roe" {
+		t.Fatalf("got beneficiary %+v", updated)
+	}
+
+	if err := app.Beneficiaries.Delete(momo.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Beneficiaries.Find(momo.ID.String()); err == nil {
+		t.Fatal("got no error finding a deleted beneficiary")
+	}
+}
+
+func TestSearchBeneficiaries(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	for _, create := range []func() (*BeneficiaryDetails, error){
+		func() (*BeneficiaryDetails, error) {
+			return app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678")
+		},
+		func() (*BeneficiaryDetails, error) {
+			return app.Beneficiaries.CreateMomoBeneficiary("Janet", "Smith", "KE", "+254712345678")
+		},
+		func() (*BeneficiaryDetails, error) {
+			return app.Beneficiaries.CreateBankBeneficiary("John", "Doe", "UG", "Stanbic", "John Doe", "SBICUGKX", "0123456789")
+		},
+	} {
+		if _, err := create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query BeneficiarySearch
+		want  int
+	}{
+		{BeneficiarySearch{Name: "jane"}, 2},
+		{BeneficiarySearch{Name: "doe"}, 2},
+		{BeneficiarySearch{Name: "jane doe"}, 1},
+		{BeneficiarySearch{PhoneNumber: "256 712 345 678"}, 1},
+		{BeneficiarySearch{BankAccountNumber: "0123456789"}, 1},
+		{BeneficiarySearch{Country: "ug"}, 2},
+		{BeneficiarySearch{Name: "jane", Country: "KE"}, 1},
+		{BeneficiarySearch{Name: "nobody"}, 0},
+	}
+
+	for _, tc := range tests {
+		matches, err := app.Beneficiaries.Search(tc.query)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(matches) != tc.want {
+			t.Errorf("Search(%+v) got %d matches, want %d", tc.query, len(matches), tc.want)
+		}
+	}
+}
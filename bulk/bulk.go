@@ -0,0 +1,375 @@
+// Package bulk makes a batch of momo and bank payouts read from a CSV or JSON file.
+//
+//	rows, err := bulk.ReadFile("payroll.csv")
+//	...
+//	results, err := bulk.OpenResults("payroll.results.jsonl")
+//	...
+//	defer results.Close()
+//
+//	engine := bulk.New(app.Payouts, bulk.WithConcurrency(8), bulk.WithResults(results))
+//	report, err := engine.Run(ctx, rows)
+//
+// Every row is validated before any payout is made. Each row is then quoted with Payout.Quotation and paid with
+// Payout.MomoPayout or Payout.BankPayout. The results file records each row under its resume key
+// (see Keys) before and after its payout, so running the batch again with the same results file resumes it:
+// completed rows are skipped, failed rows are retried and rows whose outcome is unknown are held back.
+//
+// The resume keys are only checked against the results file, they are not sent to Eversend. Running a batch
+// again without its results file, e.g after it was deleted or lost, pays every row again.
+//
+// Engine.DryRun quotes the batch without paying it and summarises it for approval, as JSON or as a table.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+)
+
+// errNoRows is returned when running an empty batch.
+var errNoRows = errors.New("bulk: the batch has no rows")
+
+// Engine runs batches of payouts.
+type Engine struct {
+	payouts     eversendSdk.PayoutService
+	wallets     eversendSdk.WalletService
+	concurrency int
+	results     *ResultsFile
+	retry       bool
+	now         func() time.Time
+}
+
+// Option is an optional setting passed to New.
+type Option func(e *Engine)
+
+// WithConcurrency sets the number of rows quoted and paid at the same time. The default is 4.
+func WithConcurrency(concurrency int) Option {
+	return func(e *Engine) {
+		e.concurrency = concurrency
+	}
+}
+
+// WithResults sets the results file the outcome of every row is written to and a resumed batch is read from.
+func WithResults(results *ResultsFile) Option {
+	return func(e *Engine) {
+		e.results = results
+	}
+}
+
+// WithRetryUnknown makes the engine send again the rows whose outcome is unknown, once you know they were not paid.
+func WithRetryUnknown() Option {
+	return func(e *Engine) {
+		e.retry = true
+	}
+}
+
+// New function to create an Engine making payouts with the PayoutService e.g app.Payouts.
+func New(payouts eversendSdk.PayoutService, opts ...Option) *Engine {
+	e := &Engine{
+		payouts:     payouts,
+		concurrency: 4,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.concurrency < 1 {
+		e.concurrency = 1
+	}
+
+	return e
+}
+
+// Report is the outcome of a run. Results are in the order of the rows.
+type Report struct {
+	Results   []Result `json:"results"`
+	Completed int      `json:"completed"`
+	Failed    int      `json:"failed"`
+	Unknown   int      `json:"unknown"`
+	Skipped   int      `json:"skipped"`
+}
+
+// Quotation is the part of a payout quotation used by the engine.
+type Quotation struct {
+	Token               string  `json:"token"`
+	SourceCurrency      string  `json:"sourceCurrency"`
+	SourceAmount        float64 `json:"sourceAmount"`
+	DestinationCurrency string  `json:"destinationCurrency"`
+	DestinationAmount   float64 `json:"destinationAmount"`
+	ExchangeRate        float64 `json:"exchangeRate"`
+	TotalFees           float64 `json:"totalFees"`
+	TotalAmount         float64 `json:"totalAmount"`
+}
+
+// Run validates the rows and, if they are all valid, quotes and pays them.
+// It returns a *ValidationError without making any payout when a row is invalid.
+// When the context is done no more rows are started, the rows in flight are finished and the report of the run so far is returned.
+func (e *Engine) Run(ctx context.Context, rows []Row) (*Report, error) {
+	if len(rows) == 0 {
+		return nil, errNoRows
+	}
+
+	if err := Validate(rows); err != nil {
+		return nil, err
+	}
+
+	keys := Keys(rows)
+	results := make([]Result, len(rows))
+	started := make([]bool, len(rows))
+
+	// the rows in flight are finished when ctx is done, a payout cancelled after it was sent has an unknown outcome
+	rowCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	var writeErr error
+	var errMutex sync.Mutex
+	sem := make(chan struct{}, e.concurrency)
+
+	for i := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		started[i] = true
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := e.runRow(rowCtx, rows[i], keys[i])
+			results[i] = result
+
+			if err != nil {
+				errMutex.Lock()
+				writeErr = errors.Join(writeErr, err)
+				errMutex.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	report := &Report{Results: []Result{}}
+
+	for i, result := range results {
+		if !started[i] {
+			continue
+		}
+
+		report.add(result)
+	}
+
+	if writeErr != nil {
+		return report, writeErr
+	}
+
+	return report, ctx.Err()
+}
+
+func (r *Report) add(result Result) {
+	r.Results = append(r.Results, result)
+
+	switch result.Status {
+	case StatusCompleted:
+		r.Completed++
+	case StatusFailed:
+		r.Failed++
+	case StatusUnknown, StatusPending:
+		r.Unknown++
+	case StatusSkipped:
+		r.Skipped++
+	}
+}
+
+// runRow quotes and pays a row, unless its previous result says otherwise. The error is a failure to write the results file.
+func (e *Engine) runRow(ctx context.Context, row Row, key string) (Result, error) {
+	result := e.newResult(row, key)
+
+	if e.results != nil {
+		if previous, ok := e.results.Latest(key); ok {
+			switch previous.Status {
+			case StatusCompleted, StatusSkipped:
+				previous.Status = StatusSkipped
+				return previous, nil
+			case StatusPending, StatusUnknown:
+				if !e.retry {
+					previous.Status = StatusUnknown
+					return previous, nil
+				}
+			}
+		}
+	}
+
+	quotation, err := Quote(ctx, e.payouts, row)
+
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result, e.write(result)
+	}
+
+	result.SourceCurrency = quotation.SourceCurrency
+	result.SourceAmount = quotation.SourceAmount
+	result.Fees = quotation.TotalFees
+	result.TotalAmount = quotation.TotalAmount
+	result.DestinationAmount = quotation.DestinationAmount
+
+	result.Status = StatusPending
+
+	// the payout is not sent unless its pending state is on disk, so a crash cannot lose a payout
+	if err := e.write(result); err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result, err
+	}
+
+	data, err := e.pay(ctx, row, quotation.Token)
+
+	switch {
+	case err != nil && sent(err):
+		result.Status = StatusUnknown
+		result.Error = err.Error()
+	case err != nil:
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	default:
+		result.Status = StatusCompleted
+		result.TransactionID = transactionID(data)
+	}
+
+	result.UpdatedAt = e.now()
+
+	return result, e.write(result)
+}
+
+func (e *Engine) newResult(row Row, key string) Result {
+	return Result{
+		Key:       key,
+		Line:      row.Line,
+		Reference: row.Reference,
+		Type:      row.Type,
+		Currency:  strings.ToUpper(row.Currency),
+		UpdatedAt: e.now(),
+	}
+}
+
+func (e *Engine) pay(ctx context.Context, row Row, token string) (map[string]interface{}, error) {
+	if row.Type == TypeBank {
+		return e.payouts.BankPayoutContext(ctx, token, row.PhoneNumber, row.FirstName, row.LastName, strings.ToUpper(row.Country),
+			row.BankName, row.BankAccountName, row.BankCode, row.BankAccountNumber)
+	}
+
+	return e.payouts.MomoPayoutContext(ctx, token, row.PhoneNumber, row.FirstName, row.LastName, strings.ToUpper(row.Country))
+}
+
+func (e *Engine) write(result Result) error {
+	if e.results == nil {
+		return nil
+	}
+
+	return e.results.Write(result)
+}
+
+// Quote gets the payout quotation of a row.
+func Quote(ctx context.Context, payouts eversendSdk.PayoutService, row Row) (*Quotation, error) {
+	data, err := payouts.QuotationContext(ctx, strings.ToUpper(row.SourceWallet), row.Amount, row.Type,
+		strings.ToUpper(row.Country), strings.ToUpper(row.Currency), row.amountType())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseQuotation(data)
+}
+
+func parseQuotation(data map[string]interface{}) (*Quotation, error) {
+	fields, _ := data["quotation"].(map[string]interface{})
+	amounts := map[string]float64{}
+
+	for _, key := range []string{"sourceAmount", "destinationAmount", "exchangeRate", "totalFees", "totalAmount"} {
+		if fields[key] == nil || fields[key] == "" {
+			continue
+		}
+
+		value, ok := eversendSdk.ParseAmount(fields[key])
+
+		if !ok {
+			return nil, fmt.Errorf("bulk: quotation %s %v is not a number", key, fields[key])
+		}
+
+		amounts[key] = value
+	}
+
+	token, _ := data["token"].(string)
+
+	if token == "" {
+		return nil, errors.New("bulk: quotation has no token")
+	}
+
+	sourceCurrency, _ := fields["sourceCurrency"].(string)
+	destinationCurrency, _ := fields["destinationCurrency"].(string)
+
+	quotation := &Quotation{
+		Token:               token,
+		SourceCurrency:      sourceCurrency,
+		SourceAmount:        amounts["sourceAmount"],
+		DestinationCurrency: destinationCurrency,
+		DestinationAmount:   amounts["destinationAmount"],
+		ExchangeRate:        amounts["exchangeRate"],
+		TotalFees:           amounts["totalFees"],
+		TotalAmount:         amounts["totalAmount"],
+	}
+
+	if _, ok := amounts["totalAmount"]; !ok {
+		// the total taken from the source wallet is the amount and the fees
+		quotation.TotalAmount = quotation.SourceAmount + quotation.TotalFees
+	}
+
+	return quotation, nil
+}
+
+// sent reports whether the error of a payout leaves it unknown whether the payout was made. Only the 4xx responses
+// rejecting it, and the pre-flight, circuit breaker and rate limiter errors returned before it is sent, show that
+// it was not made.
+// A 5xx response, e.g from a gateway timing out, or a request without a response may have been paid.
+func sent(err error) bool {
+	var apiErr *eversendSdk.APIError
+
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode < 400 || apiErr.StatusCode >= 500
+	}
+
+	return !errors.Is(err, eversendSdk.ErrInsufficientFunds) && !errors.Is(err, eversendSdk.ErrPreflightUnchecked) &&
+		!errors.Is(err, eversendSdk.ErrCircuitOpen) && !errors.Is(err, eversendSdk.ErrRateLimited)
+}
+
+func transactionID(data map[string]interface{}) string {
+	for _, key := range []string{"transactionId", "id"} {
+		switch id := data[key].(type) {
+		case string:
+			return id
+		case float64:
+			return strconv.FormatFloat(id, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
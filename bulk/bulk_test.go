@@ -0,0 +1,373 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+	"github.com/cetric32/eversend_go_sdk/mocks"
+)
+
+const batchCSV = `reference,type,sourceWallet,amount,country,currency,phoneNumber,firstName,lastName,bankName,bankAccountName,bankCode,bankAccountNumber
+pay-1,momo,UGX,10000,KE,KES,+254712345678,Jane,Doe,,,,
+pay-2,momo,UGX,20000,UG,UGX,+256712345678,John,Roe,,,,
+pay-3,bank,UGX,30000,UG,UGX,+256700000000,Mary,Moe,Stanbic,Mary Moe,SBICUGKX,0123456789
+`
+
+func newTestEngine(t *testing.T, opts ...Option) (*Engine, *eversendtest.Server) {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()))
+
+	return New(app.Payouts, opts...), srv
+}
+
+func readBatch(t *testing.T) []Row {
+	t.Helper()
+
+	rows, err := ReadCSV(strings.NewReader(batchCSV))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rows
+}
+
+func TestReadCSV(t *testing.T) {
+	rows := readBatch(t)
+
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	if rows[2].Line != 4 || rows[2].Amount != 30000 || rows[2].BankCode != "SBICUGKX" {
+		t.Fatalf("got row %+v", rows[2])
+	}
+
+	if _, err := ReadCSV(strings.NewReader("type,colour\nmomo,red\n")); err == nil {
+		t.Fatal("got no error for an unknown column")
+	}
+
+	_, err := ReadCSV(strings.NewReader("type,amount\nmomo,ten\nmomo,1\n"))
+
+	var invalid *ValidationError
+
+	if !errors.As(err, &invalid) {
+		t.Fatalf("got error %v, want a *ValidationError", err)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	rows, err := ReadJSON(strings.NewReader(`[{"reference": "pay-1", "type": "momo", "sourceWallet": "UGX", "amount": 5000}]`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0].Line != 1 || rows[0].Amount != 5000 {
+		t.Fatalf("got rows %+v", rows)
+	}
+}
+
+func TestValidateListsEveryProblem(t *testing.T) {
+	engine, srv := newTestEngine(t)
+
+	rows := readBatch(t)
+	rows[0].PhoneNumber = "not a phone"
+	rows[1].Amount = -5
+	rows[2].BankCode = ""
+	rows = append(rows, Row{Line: 5, Reference: "pay-1"})
+
+	_, err := engine.Run(context.Background(), rows)
+
+	var invalid *ValidationError
+
+	if !errors.As(err, &invalid) {
+		t.Fatalf("got error %v, want a *ValidationError", err)
+	}
+
+	fields := map[string]bool{}
+
+	for _, rowErr := range invalid.Errors {
+		fields[rowErr.Field] = true
+	}
+
+	for _, field := range []string{"phoneNumber", "amount", "bankCode", "reference", "type"} {
+		if !fields[field] {
+			t.Errorf("no error for %s in %v", field, invalid.Errors)
+		}
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts/quotation"); count != 0 {
+		t.Fatalf("got %d quotations for an invalid batch, want 0", count)
+	}
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+	results, err := OpenResults(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer results.Close()
+
+	engine, srv := newTestEngine(t, WithConcurrency(2), WithResults(results))
+
+	report, err := engine.Run(context.Background(), readBatch(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Completed != 3 || len(report.Results) != 3 {
+		t.Fatalf("got report %+v, want 3 completed rows", report)
+	}
+
+	for _, result := range report.Results {
+		if result.TransactionID == "" || result.Fees <= 0 || result.TotalAmount <= result.SourceAmount {
+			t.Errorf("got result %+v without a transaction or fees", result)
+		}
+	}
+
+	if len(srv.Transactions()) != 3 {
+		t.Fatalf("got %d transactions, want 3", len(srv.Transactions()))
+	}
+
+	written, err := LoadResults(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a pending and a completed result per row
+	if len(written) != 6 {
+		t.Fatalf("got %d results in the file, want 6", len(written))
+	}
+}
+
+func TestRunResumes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+	rows := readBatch(t)
+	keys := Keys(rows)
+
+	results, err := OpenResults(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a previous run completed the first row, crashed while paying the second and failed the third
+	results.Write(Result{Key: keys[0], Status: StatusCompleted, TransactionID: "BE1"})
+	results.Write(Result{Key: keys[1], Status: StatusPending})
+	results.Write(Result{Key: keys[2], Status: StatusFailed, Error: "Insufficient balance"})
+	results.Close()
+
+	results, err = OpenResults(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer results.Close()
+
+	engine, srv := newTestEngine(t, WithResults(results))
+
+	report, err := engine.Run(context.Background(), rows)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Skipped != 1 || report.Unknown != 1 || report.Completed != 1 {
+		t.Fatalf("got report %+v, want 1 skipped, 1 unknown and 1 completed", report)
+	}
+
+	if report.Results[0].TransactionID != "BE1" {
+		t.Fatalf("got skipped result %+v without the previous transaction", report.Results[0])
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts"); count != 1 {
+		t.Fatalf("got %d payouts, want only the failed row to be paid again", count)
+	}
+}
+
+func TestRunResumesAfterCrashWhileWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+	rows := readBatch(t)
+	keys := Keys(rows)
+
+	results, err := OpenResults(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results.Write(Result{Key: keys[0], Status: StatusCompleted, TransactionID: "BE1"})
+	results.Close()
+
+	// the crash left half of the pending result of the second row
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.WriteString(`{"key":"` + keys[1] + `","sta`)
+	f.Close()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()))
+
+	for run := 1; run <= 2; run++ {
+		results, err := OpenResults(path)
+
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+
+		report, err := New(app.Payouts, WithResults(results)).Run(context.Background(), rows)
+		results.Close()
+
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+
+		if run == 2 && report.Skipped != 3 {
+			t.Fatalf("got report %+v, want every row skipped", report)
+		}
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts"); count != 2 {
+		t.Fatalf("got %d payouts, want the 2 rows left by the crash", count)
+	}
+
+	if _, err := LoadResults(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunDoesNotRepeatPayoutsAfterServerError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+	rows := readBatch(t)[:1]
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	// the payout is made but a gateway answers with a 502
+	var failed int32
+
+	gateway := func(next eversendSdk.RoundTripFunc) eversendSdk.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+
+			if err == nil && eversendSdk.Operation(req.Context()) == "payouts.create" && atomic.AddInt32(&failed, 1) == 1 {
+				resp.Body.Close()
+				resp = &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader("Bad Gateway"))}
+			}
+
+			return resp, err
+		}
+	}
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()),
+		eversendSdk.WithMiddleware(gateway))
+
+	for run := 1; run <= 2; run++ {
+		results, err := OpenResults(path)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := New(app.Payouts, WithResults(results)).Run(context.Background(), rows)
+		results.Close()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if report.Unknown != 1 || report.Results[0].Status != StatusUnknown {
+			t.Fatalf("run %d: got report %+v, want the payout unknown", run, report)
+		}
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts"); count != 1 {
+		t.Fatalf("got %d payouts, want the resumed run not to send it again", count)
+	}
+
+	if len(srv.Transactions()) != 1 {
+		t.Fatalf("got %d transactions, want 1", len(srv.Transactions()))
+	}
+}
+
+func TestRunMarksUnansweredPayoutsUnknown(t *testing.T) {
+	var payouts int32
+
+	mock := &mocks.PayoutServiceMock{
+		QuotationContextFunc: func(ctx context.Context, sourceWallet string, amount float64, transactionType string, destinationCountry string,
+			destinationCurrency string, amountType string) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"token":     "pq_1",
+				"quotation": map[string]interface{}{"totalFees": "10.5", "totalAmount": amount + 10.5},
+			}, nil
+		},
+		MomoPayoutContextFunc: func(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
+			if atomic.AddInt32(&payouts, 1) == 1 {
+				return nil, &url.Error{Op: "Post", URL: "https://api.eversend.co/v1/payouts", Err: context.DeadlineExceeded}
+			}
+
+			return nil, &eversendSdk.APIError{StatusCode: http.StatusBadRequest, Message: "Invalid or expired token"}
+		},
+	}
+
+	rows := readBatch(t)[:2]
+
+	report, err := New(mock, WithConcurrency(1)).Run(context.Background(), rows)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Results[0].Status != StatusUnknown || report.Results[1].Status != StatusFailed {
+		t.Fatalf("got statuses %s and %s, want unknown and failed", report.Results[0].Status, report.Results[1].Status)
+	}
+
+	if report.Results[0].Fees != 10.5 {
+		t.Fatalf("got fees %v, want the fees sent as a string", report.Results[0].Fees)
+	}
+}
+
+func TestKeysAreStable(t *testing.T) {
+	rows := []Row{
+		{Type: TypeMomo, SourceWallet: "UGX", Amount: 100, Country: "UG", Currency: "UGX", PhoneNumber: "+256712345678"},
+		{Type: TypeMomo, SourceWallet: "UGX", Amount: 100, Country: "UG", Currency: "UGX", PhoneNumber: "+256712345678"},
+		{Reference: "pay-1"},
+	}
+
+	keys := Keys(rows)
+
+	if keys[0] == keys[1] {
+		t.Fatal("got the same key for two identical rows")
+	}
+
+	if again := Keys(rows); again[0] != keys[0] || again[1] != keys[1] || keys[2] != "ref:pay-1" {
+		t.Fatalf("got keys %v then %v", keys, again)
+	}
+}
@@ -0,0 +1,322 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+)
+
+// WithWallets sets the WalletService e.g app.Wallets used by DryRun to project the balance of each source wallet.
+func WithWallets(wallets eversendSdk.WalletService) Option {
+	return func(e *Engine) {
+		e.wallets = wallets
+	}
+}
+
+// DryRunRow is the quotation of a row, or why it could not be quoted.
+type DryRunRow struct {
+	Line                int      `json:"line"`
+	Reference           string   `json:"reference,omitempty"`
+	Type                string   `json:"type"`
+	SourceCurrency      string   `json:"sourceCurrency"`
+	SourceAmount        float64  `json:"sourceAmount,omitempty"`
+	DestinationCurrency string   `json:"destinationCurrency"`
+	DestinationAmount   float64  `json:"destinationAmount,omitempty"`
+	ExchangeRate        float64  `json:"exchangeRate,omitempty"`
+	Fees                float64  `json:"fees,omitempty"`
+	TotalAmount         float64  `json:"totalAmount,omitempty"`
+	Errors              []string `json:"errors,omitempty"`
+}
+
+// Flagged reports whether the row failed validation or could not be quoted.
+func (r DryRunRow) Flagged() bool {
+	return len(r.Errors) > 0
+}
+
+// CurrencyTotal is the total of the quoted rows paid from one source wallet.
+// Balance is the balance of the wallet from Wallet.Find and ProjectedBalance the balance left after the batch.
+// They are nil when the engine has no WalletService or the wallet could not be found.
+type CurrencyTotal struct {
+	Currency         string   `json:"currency"`
+	Rows             int      `json:"rows"`
+	Amount           float64  `json:"amount"`
+	Fees             float64  `json:"fees"`
+	Total            float64  `json:"total"`
+	Balance          *float64 `json:"balance,omitempty"`
+	ProjectedBalance *float64 `json:"projectedBalance,omitempty"`
+	Insufficient     bool     `json:"insufficient"`
+	BalanceError     string   `json:"balanceError,omitempty"`
+}
+
+// ExchangeRate is the rate quoted for payouts from one currency to another.
+// When rows of the pair were quoted at different rates the lowest and highest are given.
+type ExchangeRate struct {
+	From    string  `json:"from"`
+	To      string  `json:"to"`
+	Rate    float64 `json:"rate"`
+	MaxRate float64 `json:"maxRate,omitempty"`
+}
+
+// DryRunReport is the summary of a batch for approval.
+type DryRunReport struct {
+	Rows       []DryRunRow     `json:"rows"`
+	Totals     []CurrencyTotal `json:"totals"`
+	Rates      []ExchangeRate  `json:"rates"`
+	Invalid    []RowError      `json:"invalid"`
+	Quoted     int             `json:"quoted"`
+	Flagged    int             `json:"flagged"`
+	Sufficient bool            `json:"sufficient"`
+}
+
+// DryRun validates and quotes every row without making any payout, and summarises the batch.
+// Unlike Run it does not stop at invalid rows: they are flagged in the report and left out of the totals.
+func (e *Engine) DryRun(ctx context.Context, rows []Row) (*DryRunReport, error) {
+	if len(rows) == 0 {
+		return nil, errNoRows
+	}
+
+	problems := validate(rows)
+	report := &DryRunReport{
+		Rows:    make([]DryRunRow, len(rows)),
+		Invalid: []RowError{},
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, e.concurrency)
+
+	for i, row := range rows {
+		report.Rows[i] = DryRunRow{
+			Line:                row.Line,
+			Reference:           row.Reference,
+			Type:                row.Type,
+			SourceCurrency:      strings.ToUpper(row.SourceWallet),
+			DestinationCurrency: strings.ToUpper(row.Currency),
+		}
+
+		for _, rowErr := range problems[i] {
+			report.Invalid = append(report.Invalid, rowErr)
+			report.Rows[i].Errors = append(report.Rows[i].Errors, rowErr.Field+" "+rowErr.Message)
+		}
+
+		if report.Rows[i].Flagged() {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+
+		wg.Add(1)
+
+		go func(i int, row Row) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			quotation, err := Quote(ctx, e.payouts, row)
+
+			if err != nil {
+				report.Rows[i].Errors = append(report.Rows[i].Errors, "quotation: "+err.Error())
+				return
+			}
+
+			report.Rows[i].SourceCurrency = quotation.SourceCurrency
+			report.Rows[i].SourceAmount = quotation.SourceAmount
+			report.Rows[i].DestinationAmount = quotation.DestinationAmount
+			report.Rows[i].ExchangeRate = quotation.ExchangeRate
+			report.Rows[i].Fees = quotation.TotalFees
+			report.Rows[i].TotalAmount = quotation.TotalAmount
+
+			if report.Rows[i].SourceCurrency == "" {
+				report.Rows[i].SourceCurrency = strings.ToUpper(row.SourceWallet)
+			}
+		}(i, row)
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report.summarise()
+	e.projectBalances(ctx, report)
+
+	return report, nil
+}
+
+func (r *DryRunReport) summarise() {
+	totals := map[string]*CurrencyTotal{}
+	rates := map[string]*ExchangeRate{}
+
+	for _, row := range r.Rows {
+		if row.Flagged() {
+			r.Flagged++
+			continue
+		}
+
+		r.Quoted++
+
+		total, ok := totals[row.SourceCurrency]
+
+		if !ok {
+			total = &CurrencyTotal{Currency: row.SourceCurrency}
+			totals[row.SourceCurrency] = total
+		}
+
+		total.Rows++
+		total.Amount += row.SourceAmount
+		total.Fees += row.Fees
+		total.Total += row.TotalAmount
+
+		if row.SourceCurrency == row.DestinationCurrency || row.ExchangeRate == 0 {
+			continue
+		}
+
+		pair := row.SourceCurrency + "/" + row.DestinationCurrency
+		rate, ok := rates[pair]
+
+		if !ok {
+			rate = &ExchangeRate{From: row.SourceCurrency, To: row.DestinationCurrency, Rate: row.ExchangeRate, MaxRate: row.ExchangeRate}
+			rates[pair] = rate
+		}
+
+		rate.Rate = math.Min(rate.Rate, row.ExchangeRate)
+		rate.MaxRate = math.Max(rate.MaxRate, row.ExchangeRate)
+	}
+
+	r.Totals = []CurrencyTotal{}
+	r.Rates = []ExchangeRate{}
+
+	for _, total := range totals {
+		r.Totals = append(r.Totals, *total)
+	}
+
+	for _, rate := range rates {
+		if rate.MaxRate == rate.Rate {
+			rate.MaxRate = 0
+		}
+
+		r.Rates = append(r.Rates, *rate)
+	}
+
+	sort.Slice(r.Totals, func(i, j int) bool { return r.Totals[i].Currency < r.Totals[j].Currency })
+	sort.Slice(r.Rates, func(i, j int) bool {
+		return r.Rates[i].From+r.Rates[i].To < r.Rates[j].From+r.Rates[j].To
+	})
+}
+
+// projectBalances sets the balance of each source wallet and the balance left after the batch.
+func (e *Engine) projectBalances(ctx context.Context, report *DryRunReport) {
+	report.Sufficient = true
+
+	if e.wallets == nil {
+		return
+	}
+
+	for i := range report.Totals {
+		total := &report.Totals[i]
+		wallet, err := e.wallets.FindContext(ctx, total.Currency)
+
+		if err != nil {
+			total.BalanceError = err.Error()
+			report.Sufficient = false
+			continue
+		}
+
+		balance, ok := eversendSdk.WalletBalance(wallet)
+
+		if !ok {
+			total.BalanceError = "the wallet has no balance"
+			report.Sufficient = false
+			continue
+		}
+
+		projected := balance - total.Total
+		total.Balance = &balance
+		total.ProjectedBalance = &projected
+		total.Insufficient = projected < 0
+
+		if total.Insufficient {
+			report.Sufficient = false
+		}
+	}
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *DryRunReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteTable writes the report as aligned text tables for a person to read: the totals per currency,
+// the exchange rates and the flagged rows.
+func (r *DryRunReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "%d rows quoted, %d flagged\n\n", r.Quoted, r.Flagged)
+	fmt.Fprintln(tw, "CURRENCY\tROWS\tAMOUNT\tFEES\tTOTAL\tBALANCE\tAFTER BATCH\t")
+
+	for _, total := range r.Totals {
+		balance, projected := "-", "-"
+
+		if total.Balance != nil {
+			balance = formatAmount(*total.Balance)
+			projected = formatAmount(*total.ProjectedBalance)
+		}
+
+		if total.Insufficient {
+			projected += " INSUFFICIENT"
+		}
+
+		if total.BalanceError != "" {
+			balance = "error: " + total.BalanceError
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t\n", total.Currency, total.Rows, formatAmount(total.Amount),
+			formatAmount(total.Fees), formatAmount(total.Total), balance, projected)
+	}
+
+	if len(r.Rates) > 0 {
+		fmt.Fprintln(tw, "\nFROM\tTO\tRATE\t")
+
+		for _, rate := range r.Rates {
+			quoted := strconv.FormatFloat(rate.Rate, 'f', -1, 64)
+
+			if rate.MaxRate != 0 {
+				quoted += " - " + strconv.FormatFloat(rate.MaxRate, 'f', -1, 64)
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\t\n", rate.From, rate.To, quoted)
+		}
+	}
+
+	if r.Flagged > 0 {
+		fmt.Fprintln(tw, "\nLINE\tREFERENCE\tPROBLEM\t")
+
+		for _, row := range r.Rows {
+			for _, problem := range row.Errors {
+				fmt.Fprintf(tw, "%d\t%s\t%s\t\n", row.Line, row.Reference, problem)
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
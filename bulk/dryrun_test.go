@@ -0,0 +1,137 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+	"github.com/cetric32/eversend_go_sdk/mocks"
+)
+
+func TestDryRun(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithBalance("UGX", 50000))
+	t.Cleanup(srv.Close)
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()))
+	engine := New(app.Payouts, WithWallets(app.Wallets))
+
+	rows := readBatch(t)
+	rows = append(rows, Row{Line: 5, Reference: "pay-4", Type: TypeMomo, SourceWallet: "UGX", Amount: 1000,
+		Country: "UG", Currency: "UGX", PhoneNumber: "12", FirstName: "Ann", LastName: "Poe"})
+
+	report, err := engine.DryRun(context.Background(), rows)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts"); count != 0 {
+		t.Fatalf("got %d payouts in a dry run, want 0", count)
+	}
+
+	if report.Quoted != 3 || report.Flagged != 1 || len(report.Invalid) != 1 {
+		t.Fatalf("got %d quoted, %d flagged and %v invalid", report.Quoted, report.Flagged, report.Invalid)
+	}
+
+	if len(report.Totals) != 1 {
+		t.Fatalf("got totals %+v, want UGX only", report.Totals)
+	}
+
+	total := report.Totals[0]
+
+	// 60000 UGX plus the 1% payout fee of the fake
+	if total.Currency != "UGX" || total.Amount != 60000 || total.Fees != 600 || total.Total != 60600 {
+		t.Fatalf("got total %+v", total)
+	}
+
+	if total.Balance == nil || *total.Balance != 50000 || *total.ProjectedBalance != -10600 || !total.Insufficient {
+		t.Fatalf("got balance %v and projected %v, want 50000 and -10600", total.Balance, total.ProjectedBalance)
+	}
+
+	if report.Sufficient {
+		t.Fatal("got a sufficient balance for a batch above it")
+	}
+
+	if len(report.Rates) != 1 || report.Rates[0].From != "UGX" || report.Rates[0].To != "KES" || report.Rates[0].Rate == 0 {
+		t.Fatalf("got rates %+v, want UGX to KES", report.Rates)
+	}
+
+	var decoded DryRunReport
+	buf := &bytes.Buffer{}
+
+	if err := report.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded.Quoted != 3 {
+		t.Fatalf("got %v decoding the JSON report %s", err, buf.String())
+	}
+
+	buf.Reset()
+
+	if err := report.WriteTable(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"60600.00", "INSUFFICIENT", "UGX", "KES", "pay-4", "phoneNumber"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("table has no %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestDryRunFlagsQuotationFailures(t *testing.T) {
+	engine, _ := newTestEngine(t)
+
+	rows := readBatch(t)
+	rows[0].Currency = "XXX"
+
+	report, err := engine.DryRun(context.Background(), rows)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Rows[0].Flagged() || !strings.HasPrefix(report.Rows[0].Errors[0], "quotation: ") {
+		t.Fatalf("got row %+v, want a quotation error", report.Rows[0])
+	}
+
+	if report.Quoted != 2 || report.Totals[0].Balance != nil {
+		t.Fatalf("got report %+v, want 2 rows quoted and no balance without a WalletService", report)
+	}
+}
+
+func TestDryRunTotalsQuotationsWithoutTotalAmount(t *testing.T) {
+	mock := &mocks.PayoutServiceMock{
+		QuotationContextFunc: func(ctx context.Context, sourceWallet string, amount float64, transactionType string, destinationCountry string,
+			destinationCurrency string, amountType string) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"token":     "pq_1",
+				"quotation": map[string]interface{}{"sourceCurrency": "UGX", "sourceAmount": amount, "totalFees": "100"},
+			}, nil
+		},
+	}
+
+	wallets := &mocks.WalletServiceMock{
+		FindContextFunc: func(ctx context.Context, walletCurrency string) (map[string]interface{}, error) {
+			return map[string]interface{}{"currency": walletCurrency, "amount": "50000"}, nil
+		},
+	}
+
+	report, err := New(mock, WithWallets(wallets)).DryRun(context.Background(), readBatch(t)[:2])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := report.Totals[0]
+
+	if total.Total != 30200 || *total.ProjectedBalance != 19800 {
+		t.Fatalf("got total %v and projected balance %v, want the amounts and fees", total.Total, *total.ProjectedBalance)
+	}
+}
@@ -0,0 +1,195 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Status is the state of a row of a batch.
+type Status string
+
+const (
+	// StatusPending is recorded before the payout of a row is sent. A row left pending by a crash may have been paid.
+	StatusPending Status = "pending"
+	// StatusCompleted is a row whose payout was accepted.
+	StatusCompleted Status = "completed"
+	// StatusFailed is a row whose quotation or payout was rejected. It is retried when the batch is run again.
+	StatusFailed Status = "failed"
+	// StatusUnknown is a row whose payout request got no response or a 5xx response, or was left pending. It is not retried unless
+	// the engine has WithRetryUnknown, because it may have been paid. Check it with Payout.Transaction first.
+	StatusUnknown Status = "unknown"
+	// StatusSkipped is a row that was not run because it completed in a previous run.
+	StatusSkipped Status = "skipped"
+)
+
+// Result is the outcome of a row. The amounts are those of its quotation.
+type Result struct {
+	Key               string    `json:"key"`
+	Line              int       `json:"line"`
+	Reference         string    `json:"reference,omitempty"`
+	Type              string    `json:"type"`
+	Status            Status    `json:"status"`
+	TransactionID     string    `json:"transactionId,omitempty"`
+	SourceCurrency    string    `json:"sourceCurrency,omitempty"`
+	SourceAmount      float64   `json:"sourceAmount,omitempty"`
+	Fees              float64   `json:"fees,omitempty"`
+	TotalAmount       float64   `json:"totalAmount,omitempty"`
+	DestinationAmount float64   `json:"destinationAmount,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+	Error             string    `json:"error,omitempty"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// ResultsFile is an append-only JSON lines file of the results of a batch.
+// Every change of the status of a row is appended and synced to disk, so that a batch can be resumed after a crash.
+type ResultsFile struct {
+	mutex   sync.Mutex
+	file    *os.File
+	results map[string]Result
+}
+
+// OpenResults opens the results file at path, creating it if needed, and loads the results it holds.
+func OpenResults(path string) (*ResultsFile, error) {
+	results, err := LoadResults(path)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repairLastLine(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	latest := map[string]Result{}
+
+	for _, result := range results {
+		latest[result.Key] = result
+	}
+
+	return &ResultsFile{
+		file:    f,
+		results: latest,
+	}, nil
+}
+
+// repairLastLine ends the file with a newline, so that the results appended are not joined to a last line left
+// without one by a crash. A truncated last line is removed, a complete one is kept.
+func repairLastLine(f *os.File) error {
+	data, err := io.ReadAll(f)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+
+	last := bytes.LastIndexByte(data, '\n') + 1
+
+	if json.Valid(data[last:]) {
+		_, err = f.Write([]byte{'\n'})
+	} else {
+		err = f.Truncate(int64(last))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// LoadResults reads every result of a results file in the order they were written.
+// A truncated last line, left by a crash while writing, is ignored.
+func LoadResults(path string) ([]Result, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	results := []Result{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	var lastErr error
+
+	for scanner.Scan() {
+		line++
+
+		if lastErr != nil {
+			return nil, lastErr
+		}
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		result := Result{}
+
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			lastErr = fmt.Errorf("bulk: %s line %d: %w", path, line, err)
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	return results, scanner.Err()
+}
+
+// Latest returns the last result written for the key.
+func (f *ResultsFile) Latest(key string) (Result, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	result, ok := f.results[key]
+
+	return result, ok
+}
+
+// Write appends the result and syncs the file.
+func (f *ResultsFile) Write(result Result) error {
+	line, err := json.Marshal(result)
+
+	if err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if err := f.file.Sync(); err != nil {
+		return err
+	}
+
+	f.results[result.Key] = result
+
+	return nil
+}
+
+// Close closes the file.
+func (f *ResultsFile) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,212 @@
+package bulk
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The payout types of a Row.
+const (
+	TypeMomo = "momo"
+	TypeBank = "bank"
+)
+
+// Row is one payout of a batch.
+// The Reference is your own unique id of the payout. It is used as the resume key of the row when set.
+// The AmountType is "SOURCE" or "DESTINATION" as for Payout.Quotation. The default is "SOURCE".
+// The Country is the Alpha-2 country code of the recipient e.g "KE" and the Currency the currency they receive e.g "KES".
+// The bank fields are only used by bank payouts.
+type Row struct {
+	Line              int     `json:"-"`
+	Reference         string  `json:"reference,omitempty"`
+	Type              string  `json:"type"`
+	SourceWallet      string  `json:"sourceWallet"`
+	Amount            float64 `json:"amount"`
+	AmountType        string  `json:"amountType,omitempty"`
+	Country           string  `json:"country"`
+	Currency          string  `json:"currency"`
+	PhoneNumber       string  `json:"phoneNumber"`
+	FirstName         string  `json:"firstName"`
+	LastName          string  `json:"lastName"`
+	BankName          string  `json:"bankName,omitempty"`
+	BankAccountName   string  `json:"bankAccountName,omitempty"`
+	BankCode          string  `json:"bankCode,omitempty"`
+	BankAccountNumber string  `json:"bankAccountNumber,omitempty"`
+}
+
+// csvColumns maps the lower-case CSV header of each column to its Row field.
+var csvColumns = map[string]func(r *Row) *string{
+	"reference":         func(r *Row) *string { return &r.Reference },
+	"type":              func(r *Row) *string { return &r.Type },
+	"sourcewallet":      func(r *Row) *string { return &r.SourceWallet },
+	"amounttype":        func(r *Row) *string { return &r.AmountType },
+	"country":           func(r *Row) *string { return &r.Country },
+	"currency":          func(r *Row) *string { return &r.Currency },
+	"phonenumber":       func(r *Row) *string { return &r.PhoneNumber },
+	"firstname":         func(r *Row) *string { return &r.FirstName },
+	"lastname":          func(r *Row) *string { return &r.LastName },
+	"bankname":          func(r *Row) *string { return &r.BankName },
+	"bankaccountname":   func(r *Row) *string { return &r.BankAccountName },
+	"bankcode":          func(r *Row) *string { return &r.BankCode },
+	"bankaccountnumber": func(r *Row) *string { return &r.BankAccountNumber },
+}
+
+// ReadFile reads a batch from a CSV file or, if its name ends in ".json", a JSON file.
+func ReadFile(path string) ([]Row, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ReadJSON(f)
+	}
+
+	return ReadCSV(f)
+}
+
+// ReadCSV reads a batch from CSV with a header line naming the columns e.g "type,sourceWallet,amount,...".
+// The columns are the JSON names of the Row fields, matched case-insensitively, in any order.
+// An amount that is not a number is returned in a *ValidationError together with the other bad amounts.
+func ReadCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+
+	if err == io.EOF {
+		return []Row{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	amountColumn := -1
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		header[i] = name
+
+		if name == "amount" {
+			amountColumn = i
+			continue
+		}
+
+		if _, ok := csvColumns[name]; !ok {
+			return nil, fmt.Errorf("bulk: unknown column %q", name)
+		}
+	}
+
+	rows := []Row{}
+	invalid := &ValidationError{}
+
+	for {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+
+			if i != amountColumn {
+				*csvColumns[header[i]](&row) = value
+				continue
+			}
+
+			if row.Amount, err = strconv.ParseFloat(value, 64); err != nil {
+				invalid.add(row, "amount", "is not a number")
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(invalid.Errors) > 0 {
+		return rows, invalid
+	}
+
+	return rows, nil
+}
+
+// ReadJSON reads a batch from a JSON array of rows.
+func ReadJSON(r io.Reader) ([]Row, error) {
+	rows := []Row{}
+
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].Line = i + 1
+	}
+
+	return rows, nil
+}
+
+// Keys returns the resume key of each row, under which its result is recorded in the results file.
+// It is the Reference of the row or, without one, a hash of its payout details, numbered when the same details
+// appear more than once, so a row keeps its key when the batch is read again.
+func Keys(rows []Row) []string {
+	keys := make([]string, len(rows))
+	seen := map[string]int{}
+
+	for i, row := range rows {
+		if row.Reference != "" {
+			keys[i] = "ref:" + row.Reference
+			continue
+		}
+
+		hash := row.hash()
+		seen[hash]++
+		keys[i] = "row:" + hash + ":" + strconv.Itoa(seen[hash])
+	}
+
+	return keys
+}
+
+func (r Row) hash() string {
+	fields := []string{
+		strings.ToLower(r.Type),
+		strings.ToUpper(r.SourceWallet),
+		strconv.FormatFloat(r.Amount, 'f', -1, 64),
+		strings.ToUpper(r.amountType()),
+		strings.ToUpper(r.Country),
+		strings.ToUpper(r.Currency),
+		r.PhoneNumber,
+		r.BankCode,
+		r.BankAccountNumber,
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+func (r Row) amountType() string {
+	if r.AmountType == "" {
+		return "SOURCE"
+	}
+
+	return strings.ToUpper(r.AmountType)
+}
@@ -0,0 +1,143 @@
+package bulk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RowError is a problem with one field of a row.
+type RowError struct {
+	Line      int    `json:"line"`
+	Reference string `json:"reference,omitempty"`
+	Field     string `json:"field"`
+	Message   string `json:"message"`
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s %s", e.Line, e.Field, e.Message)
+}
+
+// ValidationError holds every problem found in a batch. No payout of a batch that fails validation is made.
+type ValidationError struct {
+	Errors []RowError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return "bulk: " + e.Errors[0].Error()
+	}
+
+	return fmt.Sprintf("bulk: %d invalid fields, first %s", len(e.Errors), e.Errors[0].Error())
+}
+
+func (e *ValidationError) add(row Row, field string, message string) {
+	e.Errors = append(e.Errors, RowError{
+		Line:      row.Line,
+		Reference: row.Reference,
+		Field:     field,
+		Message:   message,
+	})
+}
+
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+	countryPattern  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+	phonePattern    = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+)
+
+// Validate checks every row of the batch and returns a *ValidationError listing all the problems, or nil.
+func Validate(rows []Row) error {
+	invalid := &ValidationError{}
+
+	for _, rowErrors := range validate(rows) {
+		invalid.Errors = append(invalid.Errors, rowErrors...)
+	}
+
+	if len(invalid.Errors) > 0 {
+		return invalid
+	}
+
+	return nil
+}
+
+// validate returns the problems of each row.
+func validate(rows []Row) [][]RowError {
+	problems := make([][]RowError, len(rows))
+	references := map[string]int{}
+
+	for i, row := range rows {
+		invalid := &ValidationError{}
+		validateRow(invalid, row)
+
+		if row.Reference != "" {
+			if line, ok := references[row.Reference]; ok {
+				invalid.add(row, "reference", fmt.Sprintf("is already used on line %d", line))
+			} else {
+				references[row.Reference] = row.Line
+			}
+		}
+
+		problems[i] = invalid.Errors
+	}
+
+	return problems
+}
+
+func validateRow(invalid *ValidationError, row Row) {
+	switch row.Type {
+	case TypeMomo, TypeBank:
+	default:
+		invalid.add(row, "type", `must be "momo" or "bank"`)
+	}
+
+	if row.Amount <= 0 {
+		invalid.add(row, "amount", "must be greater than 0")
+	}
+
+	if amountType := row.amountType(); amountType != "SOURCE" && amountType != "DESTINATION" {
+		invalid.add(row, "amountType", `must be "SOURCE" or "DESTINATION"`)
+	}
+
+	if !currencyPattern.MatchString(row.SourceWallet) {
+		invalid.add(row, "sourceWallet", "must be a currency code e.g UGX")
+	}
+
+	if !currencyPattern.MatchString(row.Currency) {
+		invalid.add(row, "currency", "must be a currency code e.g KES")
+	}
+
+	if !countryPattern.MatchString(row.Country) {
+		invalid.add(row, "country", "must be an Alpha-2 country code e.g KE")
+	}
+
+	if !phonePattern.MatchString(strings.NewReplacer(" ", "", "-", "").Replace(row.PhoneNumber)) {
+		invalid.add(row, "phoneNumber", "must be a phone number in international format e.g +254712345678")
+	}
+
+	if strings.TrimSpace(row.FirstName) == "" {
+		invalid.add(row, "firstName", "is required")
+	}
+
+	if strings.TrimSpace(row.LastName) == "" {
+		invalid.add(row, "lastName", "is required")
+	}
+
+	if row.Type != TypeBank {
+		return
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"bankName", row.BankName},
+		{"bankAccountName", row.BankAccountName},
+		{"bankCode", row.BankCode},
+		{"bankAccountNumber", row.BankAccountNumber},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			invalid.add(row, field.name, "is required for a bank payout")
+		}
+	}
+}
@@ -0,0 +1,329 @@
+// Package cassette records the SDK's HTTP interactions with the Eversend API to a JSON file and replays them,
+// so integration tests recorded once against the sandbox run deterministically without network access.
+//
+//	rec, err := cassette.New("testdata/payouts.json", cassette.ModeReplay)
+//	...
+//	defer rec.Stop()
+//
+//	app := eversendSdk.NewEversendApp(clientId, clientSecret, eversendSdk.WithHTTPClient(rec.Client()))
+//
+// Tokens, secrets, phone numbers and account numbers are redacted before a cassette is written.
+// Requests are redacted the same way before they are matched, so replay works without the real values.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the real API or to its cassette.
+type Mode int
+
+const (
+	// ModeReplay serves every request from the cassette and fails requests it has no interaction for.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the API and writes the interactions to the cassette on Stop.
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if the file exists and records it otherwise.
+	ModeReplayOrRecord
+)
+
+// ErrNoInteraction is returned, wrapped, for a request that has no matching interaction in ModeReplay.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the redacted request of an Interaction. URL holds the path and query only, so a cassette
+// recorded against one host can be replayed against another.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the redacted response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	redactor  *redactor
+
+	mutex        sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Option configures a Recorder.
+type Option func(r *Recorder)
+
+// WithTransport sets the http.RoundTripper used to reach the API when recording. The default is http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithRedactedFields adds JSON fields, matched case-insensitively at any depth, whose values are redacted.
+func WithRedactedFields(fields ...string) Option {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.redactor.fields[strings.ToLower(field)] = true
+		}
+	}
+}
+
+// New function to create a Recorder for the cassette at path.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		redactor:  newRedactor(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeReplayOrRecord {
+		r.mode = ModeReplay
+
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := file{}
+
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("cassette: %s: %w", path, err)
+	}
+
+	r.interactions = f.Interactions
+	r.used = make([]bool, len(f.Interactions))
+
+	return r, nil
+}
+
+// Mode returns the mode the Recorder is running in. ModeReplayOrRecord is resolved when the Recorder is created.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an http.Client that sends its requests through the Recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, recorded)
+	}
+
+	return r.replay(req, recorded)
+}
+
+// Unused returns the interactions that were not replayed. A test can fail on them to catch requests that are no longer made.
+func (r *Recorder) Unused() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	unused := []Interaction{}
+
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+// Stop writes the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mutex.Lock()
+	content, err := json.MarshalIndent(file{Interactions: r.interactions}, "", "  ")
+	r.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(content, '\n'), 0o644)
+}
+
+// recordRequest reads and redacts the request, leaving its body readable for the transport.
+func (r *Recorder) recordRequest(req *http.Request) (RecordedRequest, error) {
+	body := []byte{}
+
+	if req.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return RecordedRequest{
+		Method:  req.Method,
+		URL:     req.URL.RequestURI(),
+		Headers: r.redactor.headers(req.Header),
+		Body:    r.redactor.body(body),
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.redactor.headers(resp.Header),
+			Body:       r.redactor.body(body),
+		},
+	})
+	r.used = append(r.used, true)
+
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request. GET requests may reuse an interaction once
+// every matching one was used, because the SDK repeats reads such as the auth token when a recorded token has expired.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reuse := -1
+
+	for i, interaction := range r.interactions {
+		if !matches(interaction.Request, recorded) {
+			continue
+		}
+
+		if !r.used[i] {
+			r.used[i] = true
+			return response(req, interaction.Response), nil
+		}
+
+		reuse = i
+	}
+
+	if reuse >= 0 && req.Method == http.MethodGet {
+		return response(req, r.interactions[reuse].Response), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s %s", ErrNoInteraction, recorded.Method, recorded.URL, recorded.Body)
+}
+
+func matches(recorded RecordedRequest, req RecordedRequest) bool {
+	return recorded.Method == req.Method && recorded.URL == req.URL && equalBodies(recorded.Body, req.Body)
+}
+
+// equalBodies compares JSON bodies by value so that key order and spacing do not matter.
+func equalBodies(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var x, y interface{}
+
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+
+	xs, _ := json.Marshal(x)
+	ys, _ := json.Marshal(y)
+
+	return bytes.Equal(xs, ys)
+}
+
+func response(req *http.Request, recorded RecordedResponse) *http.Response {
+	header := http.Header{}
+
+	for key, values := range recorded.Headers {
+		header[key] = append([]string(nil), values...)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
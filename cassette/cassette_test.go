@@ -0,0 +1,135 @@
+package cassette_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/cassette"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+const phoneNumber = "+254712345678"
+
+// payout makes the requests of the cassette: a wallet read, a quotation and the payout of its token.
+func payout(app *eversendSdk.Eversend) (map[string]interface{}, error) {
+	if _, err := app.Wallets.Find("UGX"); err != nil {
+		return nil, err
+	}
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return app.Payouts.MomoPayout(quotation["token"].(string), phoneNumber, "Jane", "Doe", "KE")
+}
+
+// record records the payout against the fake and returns the path of the cassette.
+func record(t *testing.T) string {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "payout.json")
+	rec, err := cassette.New(path, cassette.ModeRecord)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret,
+		eversendSdk.WithBaseUrl(srv.BaseURL()), eversendSdk.WithHTTPClient(rec.Client()))
+
+	if _, err := payout(app); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestRecordRedactsCredentials(t *testing.T) {
+	content, err := os.ReadFile(record(t))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{eversendtest.DefaultClientID, eversendtest.DefaultClientSecret, phoneNumber, "tok_", "Bearer"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	if !strings.Contains(string(content), `"url": "/v1/payouts"`) {
+		t.Fatalf("cassette does not have the payout:\n%s", content)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	rec, err := cassette.New(record(t), cassette.ModeReplay)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fake is closed, every response comes from the cassette
+	app := eversendSdk.NewEversendApp("other-id", "other-secret",
+		eversendSdk.WithBaseUrl("http://eversend.invalid/v1/"), eversendSdk.WithHTTPClient(rec.Client()))
+
+	result, err := payout(app)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result["status"] != "completed" || result["amount"] != float64(10000) {
+		t.Fatalf("got payout %v", result)
+	}
+
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Fatalf("got %d unused interactions", len(unused))
+	}
+
+	// a POST is replayed once
+	if _, err := app.Payouts.MomoPayout(cassette.Redacted, phoneNumber, "Jane", "Doe", "KE"); !errors.Is(err, cassette.ErrNoInteraction) {
+		t.Fatalf("got error %v, want ErrNoInteraction", err)
+	}
+
+	if _, err := app.Wallets.Find("KES"); !errors.Is(err, cassette.ErrNoInteraction) {
+		t.Fatalf("got error %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayOrRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	rec, err := cassette.New(path, cassette.ModeReplayOrRecord)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Mode() != cassette.ModeRecord {
+		t.Fatalf("got mode %v, want ModeRecord without a cassette", rec.Mode())
+	}
+
+	rec, err = cassette.New(record(t), cassette.ModeReplayOrRecord)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Mode() != cassette.ModeReplay {
+		t.Fatalf("got mode %v, want ModeReplay with a cassette", rec.Mode())
+	}
+}
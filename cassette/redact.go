@@ -0,0 +1,104 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces every redacted value in a cassette.
+const Redacted = "REDACTED"
+
+// redactedHeaders are the headers that carry credentials. Other headers are kept.
+var redactedHeaders = []string{"Authorization", "Clientid", "Clientsecret", "Cookie", "Set-Cookie"}
+
+// redactedFields are the JSON fields whose values are credentials or personal data.
+var redactedFields = []string{
+	"token",
+	"clientId",
+	"clientSecret",
+	"secret",
+	"pin",
+	"phone",
+	"phoneNumber",
+	"bankAccountNumber",
+	"accountNumber",
+	"email",
+}
+
+type redactor struct {
+	fields map[string]bool
+}
+
+func newRedactor() *redactor {
+	r := &redactor{
+		fields: map[string]bool{},
+	}
+
+	for _, field := range redactedFields {
+		r.fields[strings.ToLower(field)] = true
+	}
+
+	return r
+}
+
+func (r *redactor) headers(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := header.Clone()
+
+	for _, key := range redactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, Redacted)
+		}
+	}
+
+	return redacted
+}
+
+// body redacts the fields of a JSON body. Bodies that are not JSON are kept as they are.
+func (r *redactor) body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(r.value(value))
+
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func (r *redactor) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if r.fields[strings.ToLower(key)] && field != nil {
+				v[key] = Redacted
+				continue
+			}
+
+			v[key] = r.value(field)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,290 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped, for a request that is not sent because the circuit of its endpoint is open.
+var ErrCircuitOpen = errors.New("eversend: circuit open")
+
+// CircuitState is the state of the circuit of an endpoint.
+type CircuitState int
+
+const (
+	// CircuitClosed sends every request.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every request with ErrCircuitOpen without sending it.
+	CircuitOpen
+	// CircuitHalfOpen sends a limited number of probe requests to find out whether the endpoint has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreaker stops sending requests to an endpoint of the Eversend API after consecutive failures,
+// so that callers fail at once with ErrCircuitOpen during an outage instead of waiting for timeouts.
+// Each operation e.g "payouts.create" has its own circuit.
+//
+//	breaker := eversendSdk.NewCircuitBreaker(eversendSdk.WithFailureThreshold(5), eversendSdk.WithOpenTimeout(30*time.Second))
+//	app := eversendSdk.NewEversendApp(clientId, clientSecret, eversendSdk.WithMiddleware(breaker.Middleware()))
+//
+// A request fails when it gets no response or a 5xx response. Other responses, including 4xx, close the circuit.
+// A request cancelled or timed out by the context of the caller, or stopped by the SDK e.g with ErrRateLimited, is
+// neither a failure nor a success.
+// After the open timeout the circuit is half-open: the probe requests are sent and the circuit closes once they all succeed,
+// or opens again on the first failure.
+type CircuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	halfOpenRequests int
+	onStateChange    func(operation string, from CircuitState, to CircuitState)
+	now              func() time.Time
+
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+}
+
+// CircuitBreakerOption is an optional setting passed to NewCircuitBreaker.
+type CircuitBreakerOption func(b *CircuitBreaker)
+
+// WithFailureThreshold sets the number of consecutive failures that open a circuit. The default is 5.
+func WithFailureThreshold(failures int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.failureThreshold = failures
+	}
+}
+
+// WithOpenTimeout sets how long a circuit stays open before probe requests are sent. The default is 30 seconds.
+func WithOpenTimeout(timeout time.Duration) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.openTimeout = timeout
+	}
+}
+
+// WithHalfOpenRequests sets the number of probe requests sent while a circuit is half-open. The default is 1.
+func WithHalfOpenRequests(requests int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.halfOpenRequests = requests
+	}
+}
+
+// WithStateChange sets a function called when the circuit of an operation changes state e.g to log or alert on it.
+// It is called without locks held, but must not block.
+func WithStateChange(onStateChange func(operation string, from CircuitState, to CircuitState)) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.onStateChange = onStateChange
+	}
+}
+
+// NewCircuitBreaker function to create a CircuitBreaker. The opts are optional settings e.g WithFailureThreshold.
+func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		failureThreshold: 5,
+		openTimeout:      30 * time.Second,
+		halfOpenRequests: 1,
+		now:              time.Now,
+		circuits:         map[string]*circuit{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.failureThreshold < 1 {
+		b.failureThreshold = 1
+	}
+
+	if b.halfOpenRequests < 1 {
+		b.halfOpenRequests = 1
+	}
+
+	return b
+}
+
+// State returns the state of the circuit of an operation e.g "payouts.create".
+func (b *CircuitBreaker) State(operation string) CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[operation]
+
+	if !ok {
+		return CircuitClosed
+	}
+
+	// an open circuit whose timeout has passed lets the next request probe
+	if c.state == CircuitOpen && !b.now().Before(c.openUntil) {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+// Middleware returns the Middleware that applies the CircuitBreaker to the requests of the SDK.
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			operation := Operation(req.Context())
+
+			if err := b.allow(operation); err != nil {
+				return nil, err
+			}
+
+			resp, err := next(req)
+
+			if ignored(req, err) {
+				b.release(operation)
+				return resp, err
+			}
+
+			b.record(operation, err != nil || resp.StatusCode >= 500)
+
+			return resp, err
+		}
+	}
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	openUntil time.Time
+	// probes is the number of probe requests sent and successes the number that succeeded while half-open
+	probes    int
+	successes int
+}
+
+// allow returns ErrCircuitOpen if the request must not be sent.
+func (b *CircuitBreaker) allow(operation string) error {
+	b.mutex.Lock()
+
+	c := b.circuit(operation)
+	from := c.state
+
+	if c.state == CircuitOpen {
+		if b.now().Before(c.openUntil) {
+			b.mutex.Unlock()
+			return fmt.Errorf("%w: %s", ErrCircuitOpen, operation)
+		}
+
+		c.state = CircuitHalfOpen
+		c.probes = 0
+		c.successes = 0
+	}
+
+	if c.state == CircuitHalfOpen {
+		if c.probes >= b.halfOpenRequests {
+			b.mutex.Unlock()
+			b.changed(operation, from, c.state)
+			return fmt.Errorf("%w: %s", ErrCircuitOpen, operation)
+		}
+
+		c.probes++
+	}
+
+	to := c.state
+	b.mutex.Unlock()
+
+	b.changed(operation, from, to)
+
+	return nil
+}
+
+// ignored reports whether the error of a request says nothing about the endpoint: the caller gave up on the request,
+// or the SDK did not send it.
+func ignored(req *http.Request, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled):
+		return true
+	case errors.Is(err, context.DeadlineExceeded):
+		// the timeout of the http.Client is a failure of the endpoint, the deadline of the caller is not
+		return req.Context().Err() != nil
+	default:
+		return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrPreflightUnchecked) || errors.Is(err, ErrInsufficientFunds)
+	}
+}
+
+// release gives back the probe taken by a request whose outcome is ignored, leaving the circuit as it was.
+func (b *CircuitBreaker) release(operation string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if c := b.circuit(operation); c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// record updates the circuit with the outcome of a request.
+func (b *CircuitBreaker) record(operation string, failed bool) {
+	b.mutex.Lock()
+
+	c := b.circuit(operation)
+	from := c.state
+
+	switch {
+	case failed && c.state == CircuitHalfOpen:
+		b.open(c)
+	case failed:
+		c.failures++
+
+		if c.state == CircuitClosed && c.failures >= b.failureThreshold {
+			b.open(c)
+		}
+	case c.state == CircuitHalfOpen:
+		c.successes++
+
+		if c.successes >= b.halfOpenRequests {
+			c.state = CircuitClosed
+			c.failures = 0
+		}
+	default:
+		c.failures = 0
+	}
+
+	to := c.state
+	b.mutex.Unlock()
+
+	b.changed(operation, from, to)
+}
+
+// open opens the circuit. It is called with the mutex held.
+func (b *CircuitBreaker) open(c *circuit) {
+	c.state = CircuitOpen
+	c.failures = 0
+	c.openUntil = b.now().Add(b.openTimeout)
+}
+
+// circuit returns the circuit of the operation, creating it closed. It is called with the mutex held.
+func (b *CircuitBreaker) circuit(operation string) *circuit {
+	c, ok := b.circuits[operation]
+
+	if !ok {
+		c = &circuit{}
+		b.circuits[operation] = c
+	}
+
+	return c
+}
+
+func (b *CircuitBreaker) changed(operation string, from CircuitState, to CircuitState) {
+	if from != to && b.onStateChange != nil {
+		b.onStateChange(operation, from, to)
+	}
+}
@@ -0,0 +1,195 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newBreakerApp(t *testing.T, opts ...CircuitBreakerOption) (*Eversend, *eversendtest.Server, *CircuitBreaker, *fakeClock) {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	clock := &fakeClock{now: time.Now()}
+	breaker := NewCircuitBreaker(opts...)
+	breaker.now = clock.Now
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(breaker.Middleware()))
+
+	return app, srv, breaker, clock
+}
+
+func TestCircuitOpensAfterFailures(t *testing.T) {
+	app, srv, breaker, _ := newBreakerApp(t, WithFailureThreshold(3))
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultServerError, Path: "wallets"})
+
+	for i := 0; i < 3; i++ {
+		if _, err := app.Wallets.List(); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d: got error %v, want the server error", i, err)
+		}
+	}
+
+	if state := breaker.State("wallets.list"); state != CircuitOpen {
+		t.Fatalf("got state %s, want open", state)
+	}
+
+	if _, err := app.Wallets.List(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want ErrCircuitOpen", err)
+	}
+
+	if count := srv.RequestCount(http.MethodGet, "wallets"); count != 3 {
+		t.Fatalf("got %d requests at the server, want 3", count)
+	}
+
+	// other endpoints have their own circuit
+	if _, err := app.Payouts.DeliveryCountries(); err != nil {
+		t.Fatalf("got error %v from another endpoint", err)
+	}
+}
+
+func TestCircuitClientErrorsDoNotOpen(t *testing.T) {
+	app, _, breaker, _ := newBreakerApp(t, WithFailureThreshold(2))
+
+	for i := 0; i < 3; i++ {
+		if _, err := app.Wallets.Find("XXX"); err == nil {
+			t.Fatal("got no error for an unknown wallet")
+		}
+	}
+
+	if state := breaker.State("wallets.get"); state != CircuitClosed {
+		t.Fatalf("got state %s after 4xx responses, want closed", state)
+	}
+}
+
+func TestCircuitIgnoresCallerErrors(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	breaker := NewCircuitBreaker(WithFailureThreshold(3))
+	limiter := NewRateLimiter(RateLimit{Rate: 0.01, Burst: 1}, WithFailFast())
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()),
+		WithMiddleware(breaker.Middleware(), limiter.Middleware()))
+
+	// the rate limiter stops the requests after the first one
+	for i := 0; i < 4; i++ {
+		app.Wallets.List()
+	}
+
+	if state := breaker.State("wallets.list"); state != CircuitClosed {
+		t.Fatalf("got state %s after rate limited requests, want closed", state)
+	}
+
+	app = NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(breaker.Middleware()))
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultTimeout, Path: "account", Delay: time.Second, Times: 3})
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := app.AccountProfileContext(ctx)
+		cancel()
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("request %d: got error %v, want the deadline of the context", i, err)
+		}
+	}
+
+	if state := breaker.State("account.get"); state != CircuitClosed {
+		t.Fatalf("got state %s after the caller timed out, want closed", state)
+	}
+
+	if _, err := app.AccountProfile(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCircuitHalfOpenProbing(t *testing.T) {
+	changes := []string{}
+
+	app, srv, breaker, clock := newBreakerApp(t, WithFailureThreshold(1), WithOpenTimeout(time.Minute), WithHalfOpenRequests(2),
+		WithStateChange(func(operation string, from CircuitState, to CircuitState) {
+			if operation == "wallets.list" {
+				changes = append(changes, from.String()+">"+to.String())
+			}
+		}))
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultServerError, Path: "wallets", Times: 2})
+
+	app.Wallets.List()
+
+	clock.Add(time.Minute)
+
+	if state := breaker.State("wallets.list"); state != CircuitHalfOpen {
+		t.Fatalf("got state %s after the open timeout, want half-open", state)
+	}
+
+	// the failed probe opens the circuit again
+	if _, err := app.Wallets.List(); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want the probe to reach the server", err)
+	}
+
+	if _, err := app.Wallets.List(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want ErrCircuitOpen after a failed probe", err)
+	}
+
+	clock.Add(time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := app.Wallets.List(); err != nil {
+			t.Fatalf("probe %d: %v", i, err)
+		}
+	}
+
+	if state := breaker.State("wallets.list"); state != CircuitClosed {
+		t.Fatalf("got state %s after successful probes, want closed", state)
+	}
+
+	want := []string{"closed>open", "open>half-open", "half-open>open", "open>half-open", "half-open>closed"}
+
+	if len(changes) != len(want) {
+		t.Fatalf("got state changes %v, want %v", changes, want)
+	}
+
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("got state changes %v, want %v", changes, want)
+		}
+	}
+}
+
+func TestCircuitHalfOpenLimitsProbes(t *testing.T) {
+	breaker := NewCircuitBreaker(WithFailureThreshold(1), WithOpenTimeout(0))
+
+	breaker.record("payouts.create", true)
+
+	if err := breaker.allow("payouts.create"); err != nil {
+		t.Fatalf("got error %v for the probe", err)
+	}
+
+	if err := breaker.allow("payouts.create"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v while the probe is in flight, want ErrCircuitOpen", err)
+	}
+}
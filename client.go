@@ -0,0 +1,67 @@
+package eversendSdk
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// client holds the settings and the auth token of an app created by NewEversendApp.
+// Every app has its own, so apps of different accounts or base urls can be used at the same time.
+type client struct {
+	credentials CredentialsProvider
+	baseUrl     string
+	httpClient  *http.Client
+	middlewares []Middleware
+	duplicates  bool
+	preflight   bool
+
+	mutex            sync.Mutex
+	authToken        string
+	authTokenExpires time.Time
+	refresh          *tokenRefresh
+
+	// quotations holds the totals of the quotations created by Payout.Quotation and Exchange.Quotation for
+	// the pre-flight check, by token
+	quotationsMutex sync.Mutex
+	quotations      map[string]quotedTotal
+}
+
+func newClient(o options) *client {
+	return &client{
+		credentials: o.credentials,
+		baseUrl:     o.baseUrl,
+		httpClient:  o.httpClient,
+		middlewares: o.middlewares,
+		duplicates:  o.duplicates,
+		preflight:   o.preflight,
+		quotations:  map[string]quotedTotal{},
+	}
+}
+
+var defaultClientMutex = &sync.RWMutex{}
+
+// defaultClient is used by services not created by NewEversendApp e.g &Wallet{}. It is the client of the
+// last app created.
+var defaultClient = newClient(options{
+	credentials: StaticCredentials{},
+	baseUrl:     defaultBaseUrl,
+	httpClient:  &http.Client{},
+})
+
+// service is embedded in the services to send their requests with the client of their app.
+type service struct {
+	api *client
+}
+
+// client returns the client of the app of the service, or the default client.
+func (s *service) client() *client {
+	if s.api != nil {
+		return s.api
+	}
+
+	defaultClientMutex.RLock()
+	defer defaultClientMutex.RUnlock()
+
+	return defaultClient
+}
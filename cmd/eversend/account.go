@@ -0,0 +1,23 @@
+package main
+
+func runAccount(args []string) error {
+	flags, api := newAPIFlags("account")
+
+	if _, err := parse(flags, args, "", 0); err != nil {
+		return err
+	}
+
+	app, err := api.connect()
+
+	if err != nil {
+		return err
+	}
+
+	profile, err := app.AccountProfile()
+
+	if err != nil {
+		return err
+	}
+
+	return api.print(profile)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+)
+
+func runBeneficiaries(args []string) error {
+	sub, args, err := subcommand("beneficiaries", args, "list", "get", "create")
+
+	if err != nil {
+		return err
+	}
+
+	flags, api := newAPIFlags("beneficiaries " + sub)
+
+	switch sub {
+	case "list":
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		beneficiaries, err := app.Beneficiaries.List()
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(beneficiaries)
+	case "get":
+		values, err := parse(flags, args, "<beneficiary id>", 1)
+
+		if err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		beneficiary, err := app.Beneficiaries.Find(values[0])
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(beneficiary)
+	default:
+		beneficiaryType := flags.String("type", "momo", "beneficiary type: momo or bank")
+		recipient := newRecipientFlags(flags, true)
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		names := []string{"first-name", "last-name", "country"}
+
+		switch *beneficiaryType {
+		case "momo":
+			names = append(names, "phone")
+		case "bank":
+			names = append(names, "bank-name", "bank-account-name", "bank-code", "bank-account-number")
+		default:
+			return fmt.Errorf("unknown beneficiary type %q, use momo or bank", *beneficiaryType)
+		}
+
+		if err := required(flags, names...); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		var beneficiary *eversendSdk.BeneficiaryDetails
+
+		if *beneficiaryType == "momo" {
+			beneficiary, err = app.Beneficiaries.CreateMomoBeneficiary(*recipient.firstName, *recipient.lastName, *recipient.country, *recipient.phone)
+		} else {
+			beneficiary, err = app.Beneficiaries.CreateBankBeneficiary(*recipient.firstName, *recipient.lastName, *recipient.country,
+				*recipient.bankName, *recipient.bankAccountName, *recipient.bankCode, *recipient.bankAccountNumber)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(beneficiary)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+)
+
+// stdout is where commands print their results. It is replaced in tests.
+var stdout io.Writer = os.Stdout
+
+// apiFlags are the flags of every command calling the Eversend API.
+type apiFlags struct {
+	output     *string
+	profile    *string
+	configFile *string
+	envFile    *string
+	timeout    *time.Duration
+}
+
+// newAPIFlags returns the flag set of a command calling the Eversend API, e.g. "wallets list".
+func newAPIFlags(name string) (*flag.FlagSet, *apiFlags) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	return flags, &apiFlags{
+		output:     outputFlag(flags),
+		profile:    flags.String("profile", "", "profile of the config file e.g sandbox or prod-UG, by default $EVERSEND_PROFILE"),
+		configFile: flags.String("config", "", "config file, by default ~/.config/eversend/config.toml"),
+		envFile:    flags.String("env-file", ".env", "file of environment variables to read the credentials from, if it exists"),
+		timeout:    flags.Duration("timeout", 0, "timeout of each request, by default the timeout of the profile or 30s"),
+	}
+}
+
+// outputFlag defines the -o flag. An unknown format is rejected by flags.Parse, before any request is sent,
+// so that a payout is not sent by a command failing to print its result.
+func outputFlag(flags *flag.FlagSet) *string {
+	output := "table"
+
+	flags.Func("o", "output format: table, json or csv (default table)", func(value string) error {
+		switch value {
+		case "table", "json", "csv":
+			output = value
+			return nil
+		default:
+			return fmt.Errorf("unknown output format %q, use table, json or csv", value)
+		}
+	})
+
+	return &output
+}
+
+// parse parses the flags, which may come before, between or after the positional arguments, and checks their number.
+func parse(flags *flag.FlagSet, args []string, usage string, positional int) ([]string, error) {
+	values := []string{}
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, errUsage
+		}
+
+		args = flags.Args()
+
+		if len(args) == 0 {
+			break
+		}
+
+		values = append(values, args[0])
+		args = args[1:]
+	}
+
+	if len(values) != positional {
+		fmt.Fprintf(os.Stderr, "Usage: eversend %s %s [flags]\n", flags.Name(), usage)
+		flags.PrintDefaults()
+		return nil, errUsage
+	}
+
+	return values, nil
+}
+
+// required reports the flags that were not set, printing the usage of the command.
+func required(flags *flag.FlagSet, names ...string) error {
+	missing := []string{}
+
+	for _, name := range names {
+		if f := flags.Lookup(name); f != nil && f.Value.String() == "" {
+			missing = append(missing, "-"+name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "eversend %s: %s required\n", flags.Name(), strings.Join(missing, ", "))
+	flags.PrintDefaults()
+
+	return errUsage
+}
+
+// positive reports the amount flag if it is not above 0, printing the usage of the command.
+// Unlike the flags checked by required, an amount not set is 0 rather than empty.
+func positive(flags *flag.FlagSet, name string, value float64) error {
+	if value > 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "eversend %s: -%s must be above 0\n", flags.Name(), name)
+	flags.PrintDefaults()
+
+	return errUsage
+}
+
+// connect creates the app from the config loaded by eversendSdk.LoadConfig with the profile, config file and env file flags.
+func (f *apiFlags) connect() (*eversendSdk.Eversend, error) {
+	opts := []eversendSdk.ConfigOption{eversendSdk.WithProfile(*f.profile), eversendSdk.WithConfigFile(*f.configFile)}
+
+	if *f.envFile != "" {
+		opts = append(opts, eversendSdk.WithEnvFiles(*f.envFile))
+	} else {
+		opts = append(opts, eversendSdk.WithEnvFiles())
+	}
+
+	config, err := eversendSdk.LoadConfig(opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if *f.timeout > 0 {
+		config.Timeout = *f.timeout
+	} else if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return eversendSdk.NewEversendAppFromConfig(config), nil
+}
+
+// print writes the result of a command in the output format.
+func (f *apiFlags) print(value interface{}) error {
+	return write(stdout, *f.output, value)
+}
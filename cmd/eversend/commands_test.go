@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+// runCommand runs a command against a fake Eversend API and returns what it printed.
+func runCommand(t *testing.T, srv *eversendtest.Server, args ...string) (string, error) {
+	t.Helper()
+
+	t.Setenv("EVERSEND_CLIENT_ID", srv.ClientID)
+	t.Setenv("EVERSEND_CLIENT_SECRET", srv.ClientSecret)
+	t.Setenv("EVERSEND_BASE_URL", srv.BaseURL())
+	t.Setenv("EVERSEND_PROFILE", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	buf := &bytes.Buffer{}
+	stdout = buf
+	t.Cleanup(func() { stdout = os.Stdout })
+
+	err := commands[args[0]].run(append(args[1:], "-env-file", ""))
+
+	return buf.String(), err
+}
+
+func TestWalletsOutputFormats(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithBalance("UGX", 1000000), eversendtest.WithBalance("KES", 2500))
+	t.Cleanup(srv.Close)
+
+	table, err := runCommand(t, srv, "wallets", "list")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+
+	if len(lines) != 4 || !strings.Contains(lines[0], "CURRENCY") || !strings.Contains(table, "1000000") {
+		t.Fatalf("got table:\n%s", table)
+	}
+
+	out, err := runCommand(t, srv, "wallets", "list", "-o", "csv")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+
+	if err != nil || len(records) != 4 {
+		t.Fatalf("got %v reading CSV:\n%s", err, out)
+	}
+
+	out, err = runCommand(t, srv, "wallets", "get", "-o", "json", "KES")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wallet map[string]interface{}
+
+	if err := json.Unmarshal([]byte(out), &wallet); err != nil || wallet["currency"] != "KES" {
+		t.Fatalf("got %v decoding wallet:\n%s", err, out)
+	}
+}
+
+func TestProfileFlag(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithCredentials("ke-id", "ke-secret"))
+	t.Cleanup(srv.Close)
+
+	config := filepath.Join(t.TempDir(), "config.toml")
+	content := fmt.Sprintf("[profiles.prod-KE]\nclient_id = %q\nclient_secret = %q\nbase_url = %q\n",
+		srv.ClientID, srv.ClientSecret, srv.BaseURL())
+
+	if err := os.WriteFile(config, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runCommand(t, srv, "account"); err != nil {
+		t.Fatal(err)
+	}
+
+	// without credentials in the environment, only the profile has them
+	t.Setenv("EVERSEND_CLIENT_ID", "")
+	t.Setenv("EVERSEND_CLIENT_SECRET", "")
+	t.Setenv("EVERSEND_BASE_URL", "")
+
+	if err := commands["account"].run([]string{"-env-file", "", "-config", config, "-profile", "prod-KE"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := srv.RequestCount(http.MethodGet, "account"); count != 2 {
+		t.Fatalf("got %d requests to the server of the profile, want 2", count)
+	}
+}
+
+func TestPayoutsQuoteFlattensNestedFields(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithBalance("UGX", 50000))
+	t.Cleanup(srv.Close)
+
+	out, err := runCommand(t, srv, "payouts", "quote", "-wallet", "UGX", "-amount", "1000",
+		"-country", "UG", "-currency", "UGX")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(out, "FIELD") || !strings.Contains(out, "token") {
+		t.Fatalf("got table:\n%s", out)
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	for _, args := range [][]string{
+		{"wallets"},
+		{"wallets", "get"},
+		{"exchange", "quote", "-from", "UGX"},
+		{"payouts", "momo", "-token", "abc"},
+		{"exchange", "quote", "-from", "UGX", "-to", "KES"},
+		{"payouts", "quote", "-wallet", "UGX", "-country", "UG", "-currency", "UGX"},
+		{"payouts", "quote", "-wallet", "UGX", "-amount", "-5", "-country", "UG", "-currency", "UGX"},
+	} {
+		if _, err := runCommand(t, srv, args...); !errors.Is(err, errUsage) {
+			t.Errorf("%v: got %v, want errUsage", args, err)
+		}
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts/quotation") + srv.RequestCount(http.MethodPost, "exchanges/quotation"); count != 0 {
+		t.Errorf("got %d quotations without an amount, want 0", count)
+	}
+
+	quotation, err := runCommand(t, srv, "payouts", "quote", "-wallet", "UGX", "-amount", "1000", "-country", "UG",
+		"-currency", "UGX", "-o", "json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data map[string]interface{}
+
+	if err := json.Unmarshal([]byte(quotation), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	// an unknown output format is rejected before the payout is sent
+	for _, args := range [][]string{
+		{"wallets", "list", "-o", "xml"},
+		{"payouts", "momo", "-token", data["token"].(string), "-phone", "+256712345678", "-first-name", "Jane",
+			"-last-name", "Doe", "-country", "UG", "-o", "xml"},
+		{"exchange", "execute", "abc", "-o", "xml"},
+	} {
+		if _, err := runCommand(t, srv, args...); !errors.Is(err, errUsage) {
+			t.Errorf("%v: got %v, want errUsage", args, err)
+		}
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "payouts") + srv.RequestCount(http.MethodPost, "exchanges"); count != 0 {
+		t.Errorf("got %d payouts and exchanges with an unknown output format, want 0", count)
+	}
+}
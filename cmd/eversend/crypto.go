@@ -0,0 +1,112 @@
+package main
+
+import eversendSdk "github.com/cetric32/eversend_go_sdk"
+
+func runCrypto(args []string) error {
+	sub, args, err := subcommand("crypto", args, "assets", "addresses", "create-address", "transactions")
+
+	if err != nil {
+		return err
+	}
+
+	flags, api := newAPIFlags("crypto " + sub)
+
+	switch sub {
+	case "assets":
+		values, err := parse(flags, args, "<coin>", 1)
+
+		if err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		asset, err := app.Crypto.AssetChains(values[0])
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(asset.Chains)
+	case "addresses":
+		asset := flags.String("asset", "", "list only the addresses of this asset id e.g USDT_TRON")
+		owner := flags.String("owner", "", "list only the addresses whose owner name contains this")
+		purpose := flags.String("purpose", "", "list only the addresses with this purpose")
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		addresses, err := app.Crypto.SearchAddresses(eversendSdk.AddressSearch{AssetID: *asset, OwnerName: *owner, Purpose: *purpose})
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(addresses)
+	case "create-address":
+		asset := flags.String("asset", "", `id of the asset, see "eversend crypto assets" (required)`)
+		owner := flags.String("owner", "", "name of the owner of the address (required)")
+		description := flags.String("description", "", "description of the address")
+		purpose := flags.String("purpose", "", "purpose of the address")
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		if err := required(flags, "asset", "owner"); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		address, err := app.Crypto.CreateAddress(*asset, *owner, *description, *purpose)
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(address)
+	default:
+		// without an address, the transactions of every address are listed
+		address := flags.String("address", "", "list only the transactions of this address")
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		var transactions []eversendSdk.CryptoTransaction
+
+		if *address == "" {
+			transactions, err = app.Crypto.Transactions()
+		} else {
+			transactions, err = app.Crypto.AddressTransactions(*address)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(transactions)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+func runExchange(args []string) error {
+	sub, args, err := subcommand("exchange", args, "quote", "execute")
+
+	if err != nil {
+		return err
+	}
+
+	flags, api := newAPIFlags("exchange " + sub)
+
+	switch sub {
+	case "quote":
+		from := flags.String("from", "", "currency to convert from e.g UGX (required)")
+		to := flags.String("to", "", "currency to convert to e.g KES (required)")
+		amount := flags.Float64("amount", 0, "amount to convert (required)")
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		if err := required(flags, "from", "to"); err != nil {
+			return err
+		}
+
+		if err := positive(flags, "amount", *amount); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		quotation, err := app.Exchange.Quotation(*from, *amount, *to)
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(quotation)
+	default:
+		values, err := parse(flags, args, "<quotation token>", 1)
+
+		if err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		exchange, err := app.Exchange.Exchange(values[0])
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(exchange)
+	}
+}
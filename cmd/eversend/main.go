@@ -0,0 +1,121 @@
+// Command eversend is a command line tool for the Eversend API.
+//
+// Usage:
+//
+//	eversend <command> <subcommand> [flags]
+//
+// Run "eversend help" for the list of commands.
+//
+// Commands calling the API read the credentials from the environment variables EVERSEND_CLIENT_ID and
+// EVERSEND_CLIENT_SECRET, a .env file, or a profile of ~/.config/eversend/config.toml selected with -profile.
+// See eversendSdk.LoadConfig for the details.
+// Their results are printed as a table, or as JSON or CSV with -o json or -o csv.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// errUsage is returned by commands called with invalid arguments. The usage of the command has already been printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a top level command, e.g. "webhooks". Its run function receives the arguments after the command name.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"account": {
+		summary: "show the account profile",
+		run:     runAccount,
+	},
+	"beneficiaries": {
+		summary: "list, show and create beneficiaries",
+		run:     runBeneficiaries,
+	},
+	"crypto": {
+		summary: "list crypto assets, addresses and transactions and create addresses",
+		run:     runCrypto,
+	},
+	"exchange": {
+		summary: "quote and execute currency exchanges between wallets",
+		run:     runExchange,
+	},
+	"payouts": {
+		summary: "quote and send momo and bank payouts",
+		run:     runPayouts,
+	},
+	"wallets": {
+		summary: "list and show wallets",
+		run:     runWallets,
+	},
+	"webhooks": {
+		summary: "simulate Eversend webhooks against a local URL",
+		run:     runWebhooks,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "help" || os.Args[1] == "-h" || os.Args[1] == "--help" {
+		printUsage()
+		return
+	}
+
+	cmd, ok := commands[os.Args[1]]
+
+	if !ok {
+		fmt.Fprintf(os.Stderr, "eversend: unknown command %q\n\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+
+		fmt.Fprintln(os.Stderr, "eversend:", err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: eversend <command> <subcommand> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	names := make([]string, 0, len(commands))
+
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-15s %s\n", name, commands[name].summary)
+	}
+}
+
+// subcommand returns the subcommand name and its arguments, printing usage when it is missing.
+func subcommand(name string, args []string, subcommands ...string) (string, []string, error) {
+	if len(args) > 0 {
+		for _, sub := range subcommands {
+			if args[0] == sub {
+				return sub, args[1:], nil
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Usage: eversend %s <subcommand> [flags]\n\nSubcommands:\n", name)
+
+	for _, sub := range subcommands {
+		fmt.Fprintf(os.Stderr, "  %s\n", sub)
+	}
+
+	return "", nil, errUsage
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// write prints a result of the SDK as a table, JSON or CSV.
+// A list of objects is printed with a column per field, an object with a line per field.
+// Nested objects are flattened into fields named like "quotation.totalFees".
+func write(w io.Writer, format string, value interface{}) error {
+	generic, err := toGeneric(value)
+
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(generic)
+	case "table":
+		header, records := tabulate(generic)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+
+		for _, record := range records {
+			fmt.Fprintln(tw, strings.Join(record, "\t"))
+		}
+
+		return tw.Flush()
+	case "csv":
+		header, records := tabulate(generic)
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+
+		if err := cw.WriteAll(records); err != nil {
+			return err
+		}
+
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown output format %q, use table, json or csv", format)
+	}
+}
+
+// toGeneric converts a value to the maps, slices and scalars of encoding/json.
+func toGeneric(value interface{}) (interface{}, error) {
+	content, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+
+	if err := json.Unmarshal(content, &generic); err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+// tabulate returns the header and records of a value.
+func tabulate(value interface{}) ([]string, [][]string) {
+	switch v := value.(type) {
+	case []interface{}:
+		rows := make([]map[string]string, len(v))
+		columns := map[string]bool{}
+
+		for i, item := range v {
+			rows[i] = map[string]string{}
+			flatten(rows[i], "", item)
+
+			for column := range rows[i] {
+				columns[column] = true
+			}
+		}
+
+		header := make([]string, 0, len(columns))
+
+		for column := range columns {
+			header = append(header, column)
+		}
+
+		sort.Strings(header)
+
+		records := make([][]string, len(rows))
+
+		for i, row := range rows {
+			records[i] = make([]string, len(header))
+
+			for j, column := range header {
+				records[i][j] = row[column]
+			}
+		}
+
+		return header, records
+	case map[string]interface{}:
+		fields := map[string]string{}
+		flatten(fields, "", v)
+
+		names := make([]string, 0, len(fields))
+
+		for name := range fields {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		records := make([][]string, len(names))
+
+		for i, name := range names {
+			records[i] = []string{name, fields[name]}
+		}
+
+		return []string{"field", "value"}, records
+	default:
+		return []string{"value"}, [][]string{{scalar(v)}}
+	}
+}
+
+// flatten adds the fields of an object to fields, prefixing the names of nested fields with their parents.
+// Lists are kept as JSON. A value that is not an object is added under the name "value".
+func flatten(fields map[string]string, prefix string, value interface{}) {
+	object, ok := value.(map[string]interface{})
+
+	if !ok {
+		name := prefix
+
+		if name == "" {
+			name = "value"
+		}
+
+		fields[name] = scalar(value)
+		return
+	}
+
+	for key, field := range object {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		flatten(fields, key, field)
+	}
+}
+
+func scalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		content, _ := json.Marshal(v)
+		return string(content)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import "flag"
+
+func runPayouts(args []string) error {
+	sub, args, err := subcommand("payouts", args, "countries", "banks", "quote", "momo", "bank", "get")
+
+	if err != nil {
+		return err
+	}
+
+	flags, api := newAPIFlags("payouts " + sub)
+
+	switch sub {
+	case "countries":
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		countries, err := app.Payouts.DeliveryCountries()
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(countries)
+	case "banks":
+		values, err := parse(flags, args, "<country>", 1)
+
+		if err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		banks, err := app.Payouts.DeliveryBanks(values[0])
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(banks)
+	case "quote":
+		wallet := flags.String("wallet", "", "source wallet currency e.g UGX (required)")
+		amount := flags.Float64("amount", 0, "amount to send or receive (required)")
+		transactionType := flags.String("type", "momo", "payout type: momo or bank")
+		country := flags.String("country", "", "Alpha-2 code of the destination country e.g KE (required)")
+		currency := flags.String("currency", "", "destination currency e.g KES (required)")
+		amountType := flags.String("amount-type", "SOURCE", "SOURCE if -amount is sent, DESTINATION if it is received")
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		if err := required(flags, "wallet", "country", "currency"); err != nil {
+			return err
+		}
+
+		if err := positive(flags, "amount", *amount); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		quotation, err := app.Payouts.Quotation(*wallet, *amount, *transactionType, *country, *currency, *amountType)
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(quotation)
+	case "momo", "bank":
+		recipient := newRecipientFlags(flags, sub == "bank")
+		token := flags.String("token", "", "token of the payout quotation (required)")
+
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		names := []string{"token", "phone", "first-name", "last-name", "country"}
+
+		if sub == "bank" {
+			names = append(names, "bank-name", "bank-account-name", "bank-code", "bank-account-number")
+		}
+
+		if err := required(flags, names...); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		var payout map[string]interface{}
+
+		if sub == "momo" {
+			payout, err = app.Payouts.MomoPayout(*token, *recipient.phone, *recipient.firstName, *recipient.lastName, *recipient.country)
+		} else {
+			payout, err = app.Payouts.BankPayout(*token, *recipient.phone, *recipient.firstName, *recipient.lastName, *recipient.country,
+				*recipient.bankName, *recipient.bankAccountName, *recipient.bankCode, *recipient.bankAccountNumber)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(payout)
+	default:
+		values, err := parse(flags, args, "<transaction id>", 1)
+
+		if err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		transaction, err := app.Payouts.Transaction(values[0])
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(transaction)
+	}
+}
+
+// recipientFlags are the details of the recipient of a payout or of a beneficiary.
+type recipientFlags struct {
+	phone             *string
+	firstName         *string
+	lastName          *string
+	country           *string
+	bankName          *string
+	bankAccountName   *string
+	bankCode          *string
+	bankAccountNumber *string
+}
+
+// newRecipientFlags defines the recipient flags, with the bank account flags when bank is true.
+func newRecipientFlags(flags *flag.FlagSet, bank bool) *recipientFlags {
+	recipient := &recipientFlags{
+		phone:     flags.String("phone", "", "phone number of the recipient in international format e.g +254712345678"),
+		firstName: flags.String("first-name", "", "first name of the recipient"),
+		lastName:  flags.String("last-name", "", "last name of the recipient"),
+		country:   flags.String("country", "", "Alpha-2 code of the recipient's country e.g KE"),
+	}
+
+	if bank {
+		recipient.bankName = flags.String("bank-name", "", "name of the bank")
+		recipient.bankAccountName = flags.String("bank-account-name", "", "name of the bank account")
+		recipient.bankCode = flags.String("bank-code", "", `code of the bank, see "eversend payouts banks"`)
+		recipient.bankAccountNumber = flags.String("bank-account-number", "", "number of the bank account")
+	}
+
+	return recipient
+}
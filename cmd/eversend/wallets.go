@@ -0,0 +1,52 @@
+package main
+
+func runWallets(args []string) error {
+	sub, args, err := subcommand("wallets", args, "list", "get")
+
+	if err != nil {
+		return err
+	}
+
+	flags, api := newAPIFlags("wallets " + sub)
+
+	switch sub {
+	case "list":
+		if _, err := parse(flags, args, "", 0); err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		wallets, err := app.Wallets.List()
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(wallets)
+	default:
+		values, err := parse(flags, args, "<currency>", 1)
+
+		if err != nil {
+			return err
+		}
+
+		app, err := api.connect()
+
+		if err != nil {
+			return err
+		}
+
+		wallet, err := app.Wallets.Find(values[0])
+
+		if err != nil {
+			return err
+		}
+
+		return api.print(wallet)
+	}
+}
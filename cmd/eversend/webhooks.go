@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/webhooks"
+	"github.com/cetric32/eversend_go_sdk/webhooks/simulator"
+)
+
+func runWebhooks(args []string) error {
+	_, args, err := subcommand("webhooks", args, "simulate")
+
+	if err != nil {
+		return err
+	}
+
+	return runWebhooksSimulate(args)
+}
+
+func runWebhooksSimulate(args []string) error {
+	flags := flag.NewFlagSet("webhooks simulate", flag.ContinueOnError)
+
+	eventTypes := make([]string, 0, len(simulator.EventTypes))
+
+	for _, eventType := range simulator.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	url := flags.String("url", "", "URL of the local webhook handler (required)")
+	secret := flags.String("secret", os.Getenv("EVERSEND_WEBHOOK_SECRET"), "webhook secret, defaults to $EVERSEND_WEBHOOK_SECRET")
+	event := flags.String("event", string(webhooks.EventPayoutCompleted), "event type to send, one of "+strings.Join(eventTypes, ", ")+" or all")
+	repeat := flags.Int("repeat", 1, "number of times to deliver each event, to exercise deduplication")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each delivery")
+
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *url == "" || *secret == "" {
+		fmt.Fprintln(os.Stderr, "eversend webhooks simulate: -url and -secret are required")
+		flags.Usage()
+		return errUsage
+	}
+
+	types := []webhooks.EventType{webhooks.EventType(*event)}
+
+	if *event == "all" {
+		types = simulator.EventTypes
+	}
+
+	sim := simulator.New(*url, *secret)
+	sim.Client.Timeout = *timeout
+
+	failed := false
+
+	for _, eventType := range types {
+		sample, err := simulator.Sample(eventType)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < *repeat; i++ {
+			statusCode, body, err := sim.Send(context.Background(), sample)
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s %s -> %d %s\n", sample.Type, sample.ID, statusCode, strings.TrimSpace(string(body)))
+
+			if statusCode >= 300 {
+				failed = true
+			}
+		}
+	}
+
+	if failed {
+		return errors.New("some deliveries were not acknowledged")
+	}
+
+	return nil
+}
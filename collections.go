@@ -0,0 +1,157 @@
+package eversendSdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// CollectionFeesRequest holds the details needed to calculate the fees of a collection.
+// The Method is the collection method e.g "momo".
+type CollectionFeesRequest struct {
+	Method   string  `json:"method"`
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+}
+
+// CollectionFees holds the charges for collecting a given amount.
+type CollectionFees struct {
+	Method      string  `json:"method"`
+	Currency    string  `json:"currency"`
+	Amount      float64 `json:"amount"`
+	Charges     float64 `json:"charges"`
+	TotalAmount float64 `json:"totalAmount"`
+}
+
+// CollectionOTP holds the OTP pin id sent to the customer and, when confirming, the pin they received.
+type CollectionOTP struct {
+	PinID string `json:"pinId"`
+	Pin   string `json:"pin,omitempty"`
+}
+
+// CollectionCustomer holds optional details of the customer being collected from.
+type CollectionCustomer struct {
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Country   string `json:"country,omitempty"`
+}
+
+// MomoCollectionRequest holds the details of a mobile money(momo) collection.
+// The Phone is the customer's phone number in international format e.g "+256712345678".
+// The Country is the Alpha-2 country code of the customer e.g "UG".
+// The TransactionRef is your own unique reference for the collection.
+// The OTP is required when the customer has to confirm the collection with a pin.
+type MomoCollectionRequest struct {
+	Phone          string              `json:"phone"`
+	Amount         float64             `json:"amount"`
+	Country        string              `json:"country"`
+	Currency       string              `json:"currency"`
+	TransactionRef string              `json:"transactionRef,omitempty"`
+	Customer       *CollectionCustomer `json:"customer,omitempty"`
+	OTP            *CollectionOTP      `json:"otp,omitempty"`
+}
+
+// CollectionTransaction holds the details and status of a collection.
+type CollectionTransaction struct {
+	TransactionID  string  `json:"transactionId"`
+	TransactionRef string  `json:"transactionRef"`
+	Status         string  `json:"status"`
+	Phone          string  `json:"phone"`
+	Country        string  `json:"country"`
+	Currency       string  `json:"currency"`
+	Amount         float64 `json:"amount"`
+	Fees           float64 `json:"fees"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+// Fees function to get the fees of a collection. This is used to know the charges before collecting money from a customer.
+func (e *Collection) Fees(request CollectionFeesRequest) (*CollectionFees, error) {
+	return e.FeesContext(context.Background(), request)
+}
+
+// FeesContext is like Fees but sends the requests with ctx.
+func (e *Collection) FeesContext(ctx context.Context, request CollectionFeesRequest) (*CollectionFees, error) {
+	var responseData struct {
+		Data CollectionFees `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "collections.fees", http.MethodPost, "collections/fees", request, &responseData); err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
+
+// RequestOTP function to send an OTP to the customer's phone. This is used when the customer has to confirm a collection.
+// The returned PinID is passed to VerifyOTP or set on the MomoCollectionRequest together with the pin the customer received.
+func (e *Collection) RequestOTP(phone string) (*CollectionOTP, error) {
+	return e.RequestOTPContext(context.Background(), phone)
+}
+
+// RequestOTPContext is like RequestOTP but sends the requests with ctx.
+func (e *Collection) RequestOTPContext(ctx context.Context, phone string) (*CollectionOTP, error) {
+	var responseData struct {
+		Data CollectionOTP `json:"data"`
+	}
+
+	err := e.client().call(ctx, "collections.otp.request", http.MethodPost, "collections/otp", map[string]interface{}{
+		"phone": phone,
+	}, &responseData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
+
+// VerifyOTP function to confirm the pin the customer received from RequestOTP.
+func (e *Collection) VerifyOTP(pinId string, pin string) error {
+	return e.VerifyOTPContext(context.Background(), pinId, pin)
+}
+
+// VerifyOTPContext is like VerifyOTP but sends the requests with ctx.
+func (e *Collection) VerifyOTPContext(ctx context.Context, pinId string, pin string) error {
+	return e.client().call(ctx, "collections.otp.verify", http.MethodPost, "collections/otp/verify", CollectionOTP{
+		PinID: pinId,
+		Pin:   pin,
+	}, nil)
+}
+
+// Momo function to initiate a mobile money(momo) collection. This is used to request money from a customer's mobile money account.
+func (e *Collection) Momo(request MomoCollectionRequest) (*CollectionTransaction, error) {
+	return e.MomoContext(context.Background(), request)
+}
+
+// MomoContext is like Momo but sends the requests with ctx.
+func (e *Collection) MomoContext(ctx context.Context, request MomoCollectionRequest) (*CollectionTransaction, error) {
+	var responseData struct {
+		Data CollectionTransaction `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "collections.momo", http.MethodPost, "collections/momo", request, &responseData); err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
+
+// Status function to get the status of a collection.
+// The transactionId is the id returned when the collection was initiated.
+func (e *Collection) Status(transactionId string) (*CollectionTransaction, error) {
+	return e.StatusContext(context.Background(), transactionId)
+}
+
+// StatusContext is like Status but sends the requests with ctx.
+func (e *Collection) StatusContext(ctx context.Context, transactionId string) (*CollectionTransaction, error) {
+	var responseData struct {
+		Data CollectionTransaction `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "collections.status", http.MethodGet, "transactions/"+transactionId, nil, &responseData); err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
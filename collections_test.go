@@ -0,0 +1,96 @@
+package eversendSdk
+
+import (
+	"testing"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func TestCollectionFees(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	fees, err := app.Collections.Fees(CollectionFeesRequest{Method: "momo", Currency: "UGX", Amount: 10000})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fees.Charges != 150 || fees.TotalAmount != 10150 {
+		t.Fatalf("got fees %+v", fees)
+	}
+}
+
+func TestMomoCollectionWithOTP(t *testing.T) {
+	app, srv := newTestApp(t, eversendtest.WithBalance("UGX", 0))
+
+	otp, err := app.Collections.RequestOTP("+256712345678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if otp.PinID == "" {
+		t.Fatal("got no pin id")
+	}
+
+	if err := app.Collections.VerifyOTP(otp.PinID, "000000"); err == nil {
+		t.Fatal("got no error for a wrong pin")
+	}
+
+	if err := app.Collections.VerifyOTP(otp.PinID, eversendtest.OTPPin); err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := app.Collections.Momo(MomoCollectionRequest{
+		Phone:          "+256712345678",
+		Amount:         5000,
+		Country:        "UG",
+		Currency:       "UGX",
+		TransactionRef: "order-1",
+		OTP:            &CollectionOTP{PinID: otp.PinID, Pin: eversendtest.OTPPin},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if collection.TransactionRef != "order-1" || collection.Amount != 5000 {
+		t.Fatalf("got collection %+v", collection)
+	}
+
+	if srv.Balance("UGX") != 5000 {
+		t.Fatalf("got UGX balance %v, want 5000", srv.Balance("UGX"))
+	}
+
+	status, err := app.Collections.Status(collection.TransactionID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Status != "successful" || status.Phone != "+256712345678" {
+		t.Fatalf("got status %+v", status)
+	}
+}
+
+func TestMomoCollectionWrongOTP(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	otp, err := app.Collections.RequestOTP("+256712345678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Collections.Momo(MomoCollectionRequest{
+		Phone:    "+256712345678",
+		Amount:   5000,
+		Country:  "UG",
+		Currency: "UGX",
+		OTP:      &CollectionOTP{PinID: otp.PinID, Pin: "000000"},
+	})
+
+	if err == nil || err.Error() != "Invalid OTP" {
+		t.Fatalf("got error %v, want Invalid OTP", err)
+	}
+}
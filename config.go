@@ -0,0 +1,261 @@
+package eversendSdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+)
+
+// ErrMissingCredentials is returned by LoadConfig when no client ID or client secret was found.
+var ErrMissingCredentials = errors.New("eversend: client ID and client secret are not set")
+
+// Config is the credentials and settings of an Eversend account, as loaded by LoadConfig.
+type Config struct {
+	// Profile is the name of the profile of the config file that was used, if any.
+	Profile      string        `toml:"-"`
+	ClientID     string        `toml:"client_id"`
+	ClientSecret string        `toml:"client_secret"`
+	BaseURL      string        `toml:"base_url"`
+	Timeout      time.Duration `toml:"timeout"`
+}
+
+// String describes the config without its client secret, so that it can be logged.
+func (c Config) String() string {
+	return fmt.Sprintf("profile=%q clientId=%q baseUrl=%q timeout=%s", c.Profile, c.ClientID, c.BaseURL, c.Timeout)
+}
+
+// Options returns the options of NewEversendApp for the base URL and timeout of the config.
+func (c *Config) Options() []Option {
+	opts := []Option{}
+
+	if c.BaseURL != "" {
+		opts = append(opts, WithBaseUrl(c.BaseURL))
+	}
+
+	if c.Timeout > 0 {
+		opts = append(opts, WithHTTPClient(&http.Client{Timeout: c.Timeout}))
+	}
+
+	return opts
+}
+
+// NewEversendAppFromConfig function to create a new Eversend instance from a config returned by LoadConfig.
+// The opts are applied after those of the config, so they can override e.g the http.Client.
+func NewEversendAppFromConfig(config *Config, opts ...Option) *Eversend {
+	return NewEversendApp(config.ClientID, config.ClientSecret, append(config.Options(), opts...)...)
+}
+
+type configSettings struct {
+	profile    string
+	configFile string
+	envFiles   []string
+}
+
+// ConfigOption is an optional setting passed to LoadConfig.
+type ConfigOption func(s *configSettings)
+
+// WithProfile selects the profile of the config file e.g "sandbox" or "prod-UG".
+// It takes precedence over the EVERSEND_PROFILE variable and the default_profile of the file.
+func WithProfile(name string) ConfigOption {
+	return func(s *configSettings) {
+		s.profile = name
+	}
+}
+
+// WithConfigFile sets the path of the config file instead of ~/.config/eversend/config.toml.
+// Unlike the default file, it must exist.
+func WithConfigFile(path string) ConfigOption {
+	return func(s *configSettings) {
+		s.configFile = path
+	}
+}
+
+// WithEnvFiles sets the .env files to read instead of ".env" in the working directory.
+// Files that do not exist are ignored. The first file takes precedence over the next ones.
+func WithEnvFiles(paths ...string) ConfigOption {
+	return func(s *configSettings) {
+		s.envFiles = paths
+	}
+}
+
+// configFile is the format of ~/.config/eversend/config.toml:
+//
+//	default_profile = "sandbox"
+//
+//	[profiles.sandbox]
+//	client_id = "..."
+//	client_secret = "..."
+//	base_url = "https://sandbox-api.eversend.co/v1/"
+//
+//	[profiles.prod-UG]
+//	client_id = "..."
+//	client_secret = "..."
+//	timeout = "30s"
+type configFile struct {
+	DefaultProfile string            `toml:"default_profile"`
+	Profiles       map[string]Config `toml:"profiles"`
+}
+
+// LoadConfig loads the credentials and settings of an Eversend account from, in order of precedence:
+//
+//   - the environment variables EVERSEND_CLIENT_ID, EVERSEND_CLIENT_SECRET, EVERSEND_BASE_URL and EVERSEND_TIMEOUT,
+//   - the same variables in the .env files, which are read without changing the environment,
+//   - a profile of the config file, ~/.config/eversend/config.toml or the file named by EVERSEND_CONFIG.
+//
+// The profile is chosen by WithProfile, else EVERSEND_PROFILE, else the default_profile of the file.
+// A profile chosen by WithProfile takes precedence over the client ID, client secret and base URL of the .env files,
+// only the environment variables override it.
+// Asking for a profile that is not in the file is an error.
+// ErrMissingCredentials is returned if the client ID or secret is not set anywhere.
+func LoadConfig(opts ...ConfigOption) (*Config, error) {
+	settings := configSettings{
+		envFiles: []string{".env"},
+	}
+
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	dotenv := map[string]string{}
+
+	// the first file wins, so the files are read from the last
+	for i := len(settings.envFiles) - 1; i >= 0; i-- {
+		values, err := godotenv.Read(settings.envFiles[i])
+
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("eversend: reading %s: %w", settings.envFiles[i], err)
+		}
+
+		for key, value := range values {
+			dotenv[key] = value
+		}
+	}
+
+	lookup := func(key string) string {
+		if value, ok := os.LookupEnv(key); ok && value != "" {
+			return value
+		}
+
+		return dotenv[key]
+	}
+
+	config, err := loadProfile(settings, lookup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the credentials of the .env files may be of another account e.g the sandbox, so they do not override a
+	// profile selected with WithProfile
+	account := lookup
+
+	if settings.profile != "" {
+		account = os.Getenv
+	}
+
+	for key, field := range map[string]*string{
+		"EVERSEND_CLIENT_ID":     &config.ClientID,
+		"EVERSEND_CLIENT_SECRET": &config.ClientSecret,
+		"EVERSEND_BASE_URL":      &config.BaseURL,
+	} {
+		if value := account(key); value != "" {
+			*field = value
+		}
+	}
+
+	if value := lookup("EVERSEND_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("eversend: EVERSEND_TIMEOUT: %w", err)
+		}
+
+		config.Timeout = timeout
+	}
+
+	if config.ClientID == "" || config.ClientSecret == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	return config, nil
+}
+
+// loadProfile returns the selected profile of the config file, or an empty config when there is no file.
+func loadProfile(settings configSettings, lookup func(key string) string) (*Config, error) {
+	path := settings.configFile
+
+	if path == "" {
+		path = lookup("EVERSEND_CONFIG")
+	}
+
+	explicit := path != ""
+
+	if !explicit {
+		path = defaultConfigFile()
+	}
+
+	profile := settings.profile
+
+	if profile == "" {
+		profile = lookup("EVERSEND_PROFILE")
+	}
+
+	var file configFile
+
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		if !errors.Is(err, os.ErrNotExist) || explicit {
+			return nil, fmt.Errorf("eversend: reading config file: %w", err)
+		}
+
+		if profile != "" {
+			return nil, fmt.Errorf("eversend: profile %q not found, %s does not exist", profile, path)
+		}
+
+		return &Config{}, nil
+	}
+
+	if profile == "" {
+		profile = file.DefaultProfile
+	}
+
+	if profile == "" {
+		return &Config{}, nil
+	}
+
+	config, ok := file.Profiles[profile]
+
+	if !ok {
+		return nil, fmt.Errorf("eversend: profile %q not found in %s", profile, path)
+	}
+
+	config.Profile = profile
+
+	return &config, nil
+}
+
+// defaultConfigFile returns the path of config.toml in $XDG_CONFIG_HOME/eversend, by default ~/.config/eversend.
+func defaultConfigFile() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+
+	if dir == "" {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return ""
+		}
+
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "eversend", "config.toml")
+}
@@ -0,0 +1,169 @@
+package eversendSdk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfigFile = `
+default_profile = "sandbox"
+
+[profiles.sandbox]
+client_id = "sandbox-id"
+client_secret = "sandbox-secret"
+base_url = "https://sandbox.example.com/v1/"
+
+[profiles.prod-UG]
+client_id = "ug-id"
+client_secret = "ug-secret"
+timeout = "45s"
+`
+
+// setConfigEnv clears the Eversend variables and points the config at files in a temporary directory.
+func setConfigEnv(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for _, key := range []string{"EVERSEND_CLIENT_ID", "EVERSEND_CLIENT_SECRET", "EVERSEND_BASE_URL",
+		"EVERSEND_TIMEOUT", "EVERSEND_PROFILE", "EVERSEND_CONFIG"} {
+		t.Setenv(key, "")
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "eversend"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "eversend", "config.toml"), []byte(testConfigFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestLoadConfigProfiles(t *testing.T) {
+	dir := setConfigEnv(t)
+	noEnv := WithEnvFiles(filepath.Join(dir, "missing.env"))
+
+	config, err := LoadConfig(noEnv)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Profile != "sandbox" || config.ClientID != "sandbox-id" || config.BaseURL != "https://sandbox.example.com/v1/" {
+		t.Fatalf("got %+v, want the default sandbox profile", *config)
+	}
+
+	config, err = LoadConfig(noEnv, WithProfile("prod-UG"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.ClientSecret != "ug-secret" || config.Timeout != 45*time.Second || config.BaseURL != "" {
+		t.Fatalf("got %+v, want the prod-UG profile", *config)
+	}
+
+	t.Setenv("EVERSEND_PROFILE", "prod-UG")
+
+	if config, err = LoadConfig(noEnv); err != nil || config.Profile != "prod-UG" {
+		t.Fatalf("got %v and %v, want the profile of EVERSEND_PROFILE", config, err)
+	}
+
+	if _, err := LoadConfig(noEnv, WithProfile("prod-KE")); err == nil || !strings.Contains(err.Error(), `"prod-KE"`) {
+		t.Fatalf("got %v, want an error for an unknown profile", err)
+	}
+
+	if strings.Contains(config.String(), "ug-secret") {
+		t.Fatalf("String shows the client secret: %s", config)
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	dir := setConfigEnv(t)
+	envFile := filepath.Join(dir, "test.env")
+	content := "EVERSEND_CLIENT_ID=dotenv-id\nEVERSEND_CLIENT_SECRET=dotenv-secret\nEVERSEND_TIMEOUT=5s\n"
+
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("EVERSEND_CLIENT_SECRET", "env-secret")
+
+	config, err := LoadConfig(WithEnvFiles(envFile))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the environment overrides the .env file, which overrides the profile
+	if config.ClientID != "dotenv-id" || config.ClientSecret != "env-secret" || config.Timeout != 5*time.Second ||
+		config.BaseURL != "https://sandbox.example.com/v1/" {
+		t.Fatalf("got %+v", *config)
+	}
+
+	if os.Getenv("EVERSEND_CLIENT_ID") != "" {
+		t.Fatal("LoadConfig changed the environment")
+	}
+}
+
+func TestLoadConfigProfileOverridesEnvFile(t *testing.T) {
+	dir := setConfigEnv(t)
+	envFile := filepath.Join(dir, "test.env")
+	content := "EVERSEND_CLIENT_ID=dotenv-id\nEVERSEND_CLIENT_SECRET=dotenv-secret\nEVERSEND_BASE_URL=https://sandbox.example.com/v1/\n"
+
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(WithEnvFiles(envFile), WithProfile("prod-UG"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.ClientID != "ug-id" || config.ClientSecret != "ug-secret" || config.BaseURL != "" {
+		t.Fatalf("got %+v, want the prod-UG profile without the .env credentials", *config)
+	}
+
+	t.Setenv("EVERSEND_CLIENT_ID", "env-id")
+
+	if config, err = LoadConfig(WithEnvFiles(envFile), WithProfile("prod-UG")); err != nil || config.ClientID != "env-id" {
+		t.Fatalf("got %v and %v, want the client ID of the environment", config, err)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := setConfigEnv(t)
+	noEnv := WithEnvFiles()
+
+	if _, err := LoadConfig(noEnv, WithConfigFile(filepath.Join(dir, "missing.toml"))); err == nil {
+		t.Fatal("got no error for a config file that does not exist")
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "empty"))
+
+	if _, err := LoadConfig(noEnv); !errors.Is(err, ErrMissingCredentials) {
+		t.Fatalf("got %v, want ErrMissingCredentials", err)
+	}
+
+	t.Setenv("EVERSEND_CLIENT_ID", "id")
+	t.Setenv("EVERSEND_CLIENT_SECRET", "secret")
+
+	if _, err := LoadConfig(noEnv); err != nil {
+		t.Fatalf("got %v, want the credentials of the environment without a config file", err)
+	}
+
+	t.Setenv("EVERSEND_TIMEOUT", "soon")
+
+	if _, err := LoadConfig(noEnv); err == nil {
+		t.Fatal("got no error for an invalid EVERSEND_TIMEOUT")
+	}
+}
@@ -0,0 +1,268 @@
+package eversendSdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are the client ID and client secret used to get an auth token.
+type Credentials struct {
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+// CredentialsProvider returns the credentials of the account. It is consulted every time a new auth token is needed,
+// so credentials rotated by the provider are used from the next token without restarting the app.
+// It must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials() (Credentials, error)
+}
+
+// CredentialsInvalidator is implemented by the providers that cache credentials, such as FileCredentials and
+// ExecCredentials. Invalidate is called when the API rejects the credentials or a token got with them, so that
+// the next call of Credentials fetches them again e.g after they were rotated.
+type CredentialsInvalidator interface {
+	Invalidate()
+}
+
+// WithCredentialsProvider sets the provider of the credentials instead of the clientId and clientSecret
+// passed to NewEversendApp, which are then ignored.
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(o *options) {
+		o.credentials = provider
+	}
+}
+
+// StaticCredentials is a CredentialsProvider of fixed credentials. It is the provider used by default.
+type StaticCredentials Credentials
+
+// NewStaticCredentials returns a provider of the given credentials.
+func NewStaticCredentials(clientId string, clientSecret string) StaticCredentials {
+	return StaticCredentials{ClientID: clientId, ClientSecret: clientSecret}
+}
+
+// Credentials returns the credentials.
+func (c StaticCredentials) Credentials() (Credentials, error) {
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return Credentials{}, ErrMissingCredentials
+	}
+
+	return Credentials(c), nil
+}
+
+// EnvCredentials is a CredentialsProvider reading environment variables when a token is needed.
+type EnvCredentials struct {
+	clientIdVar     string
+	clientSecretVar string
+}
+
+// NewEnvCredentials returns a provider reading the credentials from the environment variables named
+// clientIdVar and clientSecretVar, by default EVERSEND_CLIENT_ID and EVERSEND_CLIENT_SECRET.
+func NewEnvCredentials(clientIdVar string, clientSecretVar string) *EnvCredentials {
+	if clientIdVar == "" {
+		clientIdVar = "EVERSEND_CLIENT_ID"
+	}
+
+	if clientSecretVar == "" {
+		clientSecretVar = "EVERSEND_CLIENT_SECRET"
+	}
+
+	return &EnvCredentials{clientIdVar: clientIdVar, clientSecretVar: clientSecretVar}
+}
+
+// Credentials returns the credentials of the environment.
+func (c *EnvCredentials) Credentials() (Credentials, error) {
+	credentials := Credentials{
+		ClientID:     os.Getenv(c.clientIdVar),
+		ClientSecret: os.Getenv(c.clientSecretVar),
+	}
+
+	if credentials.ClientID == "" || credentials.ClientSecret == "" {
+		return Credentials{}, fmt.Errorf("%w: %s and %s must be set", ErrMissingCredentials, c.clientIdVar, c.clientSecretVar)
+	}
+
+	return credentials, nil
+}
+
+// FileCredentials is a CredentialsProvider reading the client ID and secret from two files,
+// such as the keys of a Kubernetes secret mounted as a volume.
+// The files are read again when their modification time or size changes, so a rotated secret is picked up
+// without a restart. Leading and trailing white space is ignored.
+type FileCredentials struct {
+	clientIdPath     string
+	clientSecretPath string
+
+	mutex       sync.Mutex
+	versions    [2]fileVersion
+	credentials Credentials
+}
+
+// fileVersion identifies the content of a file read by FileCredentials.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials returns a provider reading the client ID from clientIdPath and the secret from clientSecretPath
+// e.g "/var/run/secrets/eversend/client_id" and "/var/run/secrets/eversend/client_secret".
+func NewFileCredentials(clientIdPath string, clientSecretPath string) *FileCredentials {
+	return &FileCredentials{clientIdPath: clientIdPath, clientSecretPath: clientSecretPath}
+}
+
+// Credentials returns the content of the files, reading them again if they changed.
+func (c *FileCredentials) Credentials() (Credentials, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	paths := [2]string{c.clientIdPath, c.clientSecretPath}
+	versions := [2]fileVersion{}
+
+	for i, path := range paths {
+		// Stat follows symbolic links, so the ..data link swapped by Kubernetes is seen as a change
+		info, err := os.Stat(path)
+
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		versions[i] = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	if versions == c.versions && c.credentials.ClientID != "" {
+		return c.credentials, nil
+	}
+
+	values := [2]string{}
+
+	for i, path := range paths {
+		content, err := os.ReadFile(path)
+
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		values[i] = strings.TrimSpace(string(content))
+	}
+
+	if values[0] == "" || values[1] == "" {
+		return Credentials{}, fmt.Errorf("%w: %s or %s is empty", ErrMissingCredentials, c.clientIdPath, c.clientSecretPath)
+	}
+
+	c.versions = versions
+	c.credentials = Credentials{ClientID: values[0], ClientSecret: values[1]}
+
+	return c.credentials, nil
+}
+
+// Invalidate makes the next call of Credentials read the files again.
+func (c *FileCredentials) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.versions = [2]fileVersion{}
+}
+
+// ExecCredentials is a CredentialsProvider running a command, such as a secret manager client, that prints
+// the credentials as JSON on its standard output:
+//
+//	{"clientId": "...", "clientSecret": "...", "expiresAt": "2024-01-02T15:04:05Z"}
+//
+// The credentials are kept until expiresAt, or if it is not given, the command is run every time a token is needed.
+// They are also dropped when the API rejects them.
+type ExecCredentials struct {
+	command string
+	args    []string
+	timeout time.Duration
+
+	mutex       sync.Mutex
+	credentials Credentials
+	expires     time.Time
+	now         func() time.Time
+}
+
+// ExecOption is an optional setting passed to NewExecCredentials.
+type ExecOption func(c *ExecCredentials)
+
+// WithExecTimeout sets how long the command may run. The default is 30 seconds.
+func WithExecTimeout(timeout time.Duration) ExecOption {
+	return func(c *ExecCredentials) {
+		c.timeout = timeout
+	}
+}
+
+// NewExecCredentials returns a provider running the command with the args to get the credentials.
+func NewExecCredentials(command string, args []string, opts ...ExecOption) *ExecCredentials {
+	c := &ExecCredentials{
+		command: command,
+		args:    args,
+		timeout: 30 * time.Second,
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Credentials returns the credentials printed by the command, running it unless the last ones have not expired.
+func (c *ExecCredentials) Credentials() (Credentials, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.credentials.ClientID != "" && c.expires.After(c.now()) {
+		return c.credentials, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.command, c.args...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	output, err := cmd.Output()
+
+	if err != nil {
+		// the output may hold secrets, only the error output is reported
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return Credentials{}, fmt.Errorf("eversend: credentials command %s: %w: %.200s", c.command, err, message)
+		}
+
+		return Credentials{}, fmt.Errorf("eversend: credentials command %s: %w", c.command, err)
+	}
+
+	var result struct {
+		Credentials
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return Credentials{}, fmt.Errorf("eversend: credentials command %s printed invalid JSON", c.command)
+	}
+
+	if result.ClientID == "" || result.ClientSecret == "" {
+		return Credentials{}, fmt.Errorf("%w: credentials command %s printed no clientId or clientSecret", ErrMissingCredentials, c.command)
+	}
+
+	c.credentials = result.Credentials
+	c.expires = result.ExpiresAt
+
+	return c.credentials, nil
+}
+
+// Invalidate makes the next call of Credentials run the command.
+func (c *ExecCredentials) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.expires = time.Time{}
+}
@@ -0,0 +1,232 @@
+package eversendSdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("TEST_EVERSEND_ID", "id")
+	t.Setenv("TEST_EVERSEND_SECRET", "")
+
+	provider := NewEnvCredentials("TEST_EVERSEND_ID", "TEST_EVERSEND_SECRET")
+
+	if _, err := provider.Credentials(); !errors.Is(err, ErrMissingCredentials) {
+		t.Fatalf("got %v, want ErrMissingCredentials", err)
+	}
+
+	t.Setenv("TEST_EVERSEND_SECRET", "secret")
+
+	if credentials, err := provider.Credentials(); err != nil || credentials != (Credentials{"id", "secret"}) {
+		t.Fatalf("got %+v and %v", credentials, err)
+	}
+}
+
+// writeSecret writes a secret file with a modification time that differs from the previous one.
+func writeSecret(t *testing.T, path string, value string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(value+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileCredentialsReloadsRotatedSecrets(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	idPath, secretPath := filepath.Join(dir, "client_id"), filepath.Join(dir, "client_secret")
+	start := time.Now().Add(-time.Hour)
+
+	writeSecret(t, idPath, srv.ClientID, start)
+	writeSecret(t, secretPath, "old-secret", start)
+
+	app := NewEversendApp("", "", WithBaseUrl(srv.BaseURL()),
+		WithCredentialsProvider(NewFileCredentials(idPath, secretPath)))
+
+	if _, err := app.Wallets.List(); err == nil {
+		t.Fatal("got no error with a wrong client secret")
+	}
+
+	writeSecret(t, secretPath, srv.ClientSecret, start.Add(time.Minute))
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatalf("got %v after the secret was rotated", err)
+	}
+}
+
+func TestExecCredentials(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("no /bin/sh to run the credentials command")
+	}
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "output.json")
+	script := filepath.Join(dir, "credentials.sh")
+
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat \"$1\"\necho called >> \"$1.calls\"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	content := fmt.Sprintf(`{"clientId": "id", "clientSecret": "secret", "expiresAt": %q}`, expires)
+
+	if err := os.WriteFile(output, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewExecCredentials(script, []string{output})
+
+	for i := 0; i < 2; i++ {
+		if credentials, err := provider.Credentials(); err != nil || credentials != (Credentials{"id", "secret"}) {
+			t.Fatalf("got %+v and %v", credentials, err)
+		}
+	}
+
+	if calls, _ := os.ReadFile(output + ".calls"); strings.Count(string(calls), "called") != 1 {
+		t.Fatalf("the command ran %d times, want once before the credentials expire", strings.Count(string(calls), "called"))
+	}
+
+	// after expiry the command runs again and its failure is reported without its output
+	provider.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	if err := os.WriteFile(output, []byte(`{"clientSecret": "leaked"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Credentials(); !errors.Is(err, ErrMissingCredentials) || strings.Contains(err.Error(), "leaked") {
+		t.Fatalf("got %v, want ErrMissingCredentials", err)
+	}
+
+	failing := NewExecCredentials(filepath.Join(dir, "missing.sh"), nil)
+
+	if _, err := failing.Credentials(); err == nil {
+		t.Fatal("got no error for a command that does not exist")
+	}
+}
+
+// rotatingCredentials caches the current credentials until they are invalidated.
+type rotatingCredentials struct {
+	mutex       sync.Mutex
+	current     Credentials
+	cached      Credentials
+	invalidated int
+}
+
+func (c *rotatingCredentials) Credentials() (Credentials, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.cached.ClientID == "" {
+		c.cached = c.current
+	}
+
+	return c.cached, nil
+}
+
+func (c *rotatingCredentials) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cached = Credentials{}
+	c.invalidated++
+}
+
+func TestRotatedCredentialsAreFetchedAgain(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithCredentials("id", "new-secret"))
+	t.Cleanup(srv.Close)
+
+	provider := &rotatingCredentials{
+		current: Credentials{"id", "new-secret"},
+		cached:  Credentials{"id", "old-secret"},
+	}
+
+	app := NewEversendApp("", "", WithBaseUrl(srv.BaseURL()), WithCredentialsProvider(provider))
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if provider.invalidated != 1 {
+		t.Fatalf("provider invalidated %d times, want once", provider.invalidated)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests, want 2", got)
+	}
+
+	// a token revoked with the credentials drops them too
+	srv.ExpireTokens()
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if provider.invalidated != 2 {
+		t.Fatalf("provider invalidated %d times, want twice", provider.invalidated)
+	}
+}
+
+func TestRejectedCredentialsAreFetchedOnce(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithCredentials("id", "secret"))
+	t.Cleanup(srv.Close)
+
+	provider := &rotatingCredentials{current: Credentials{"id", "revoked"}}
+	app := NewEversendApp("", "", WithBaseUrl(srv.BaseURL()), WithCredentialsProvider(provider))
+
+	if _, err := app.Wallets.List(); err == nil || err.Error() != "Invalid client credentials" {
+		t.Fatalf("got error %v, want Invalid client credentials", err)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests, want 2", got)
+	}
+}
+
+func TestInvalidateRunsCommandAgain(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("no /bin/sh to run the credentials command")
+	}
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "output.json")
+	script := filepath.Join(dir, "credentials.sh")
+
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat \"$1\"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(secret string) {
+		content := fmt.Sprintf(`{"clientId": "id", "clientSecret": %q, "expiresAt": %q}`, secret,
+			time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+		if err := os.WriteFile(output, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("old-secret")
+	provider := NewExecCredentials(script, []string{output})
+	provider.Credentials()
+
+	write("new-secret")
+	provider.Invalidate()
+
+	if credentials, err := provider.Credentials(); err != nil || credentials.ClientSecret != "new-secret" {
+		t.Fatalf("got %+v and %v, want the rotated secret", credentials, err)
+	}
+}
@@ -0,0 +1,307 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidAsset is matched by the error returned by CreateAddress for an asset id that is not one of the asset chains.
+var ErrInvalidAsset = errors.New("invalid crypto asset")
+
+// ErrAddressNotFound is returned by FindAddress when no saved address matches.
+var ErrAddressNotFound = errors.New("crypto address not found")
+
+// InvalidAssetError is returned by CreateAddress when the asset id is not in the AssetChains of its coin.
+// No address is created. Valid holds the asset ids of the coin.
+type InvalidAssetError struct {
+	AssetID string
+	Valid   []string
+}
+
+func (e *InvalidAssetError) Error() string {
+	if len(e.Valid) == 0 {
+		return fmt.Sprintf("%s: %s", ErrInvalidAsset.Error(), e.AssetID)
+	}
+
+	return fmt.Sprintf("%s: %s, valid assets are %s", ErrInvalidAsset.Error(), e.AssetID, strings.Join(e.Valid, ", "))
+}
+
+// Is reports whether target is ErrInvalidAsset.
+func (e *InvalidAssetError) Is(target error) bool {
+	return target == ErrInvalidAsset
+}
+
+// CryptoChain is a chain on which a coin can be received. The AssetID is passed to CreateAddress.
+type CryptoChain struct {
+	AssetID string `json:"assetId"`
+	Chain   string `json:"chain"`
+	Name    string `json:"name"`
+}
+
+// CryptoAsset holds the chains of a coin e.g "USDT" on "TRON" and "ETH".
+type CryptoAsset struct {
+	Coin   string        `json:"coin"`
+	Chains []CryptoChain `json:"chains"`
+}
+
+// FindChain returns the chain of the asset id, if the coin has it.
+func (a *CryptoAsset) FindChain(assetId string) (CryptoChain, bool) {
+	for _, chain := range a.Chains {
+		if strings.EqualFold(chain.AssetID, assetId) {
+			return chain, true
+		}
+	}
+
+	return CryptoChain{}, false
+}
+
+// CryptoAddress holds the details of a crypto address created with CreateAddress.
+type CryptoAddress struct {
+	ID                            string `json:"id"`
+	Address                       string `json:"address"`
+	AssetID                       string `json:"assetId"`
+	Coin                          string `json:"coin"`
+	Chain                         string `json:"chain"`
+	OwnerName                     string `json:"ownerName"`
+	DestinationAddressDescription string `json:"destinationAddressDescription"`
+	Purpose                       string `json:"purpose"`
+	CreatedAt                     string `json:"createdAt"`
+}
+
+// CryptoTransaction holds the details and status of a crypto transaction e.g a deposit to an address.
+// The Status is "pending" until the transaction has enough Confirmations, then "completed".
+type CryptoTransaction struct {
+	ID            string  `json:"id"`
+	Type          string  `json:"type"`
+	Status        string  `json:"status"`
+	Address       string  `json:"address"`
+	AssetID       string  `json:"assetId"`
+	Coin          string  `json:"coin"`
+	Chain         string  `json:"chain"`
+	Amount        float64 `json:"amount"`
+	TxHash        string  `json:"txHash"`
+	Confirmations int     `json:"confirmations"`
+	CreatedAt     string  `json:"createdAt"`
+}
+
+// AddressSearch holds the criteria used by SearchAddresses. Empty fields are ignored and an address must match all the others.
+// The AssetID and Purpose are matched case-insensitively, the OwnerName case-insensitively against part of the name.
+type AddressSearch struct {
+	AssetID   string
+	OwnerName string
+	Purpose   string
+}
+
+// AssetChains function to get a list of asset chains. This is used to get the asset chains you can use to send money.
+// The coin is the currency you want to get the asset chains for e.g "USDT".
+func (e *Crypto) AssetChains(coin string) (*CryptoAsset, error) {
+	return e.AssetChainsContext(context.Background(), coin)
+}
+
+// AssetChainsContext is like AssetChains but sends the requests with ctx.
+func (e *Crypto) AssetChainsContext(ctx context.Context, coin string) (*CryptoAsset, error) {
+	var responseData struct {
+		Data CryptoAsset `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "crypto.assets", http.MethodGet, "crypto/assets/"+coin, nil, &responseData); err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
+
+// Addresses function to get a list of addresses. This is used to get the addresses you have saved.
+func (e *Crypto) Addresses() ([]CryptoAddress, error) {
+	return e.AddressesContext(context.Background())
+}
+
+// AddressesContext is like Addresses but sends the requests with ctx.
+func (e *Crypto) AddressesContext(ctx context.Context) ([]CryptoAddress, error) {
+	var responseData struct {
+		Data struct {
+			Addresses []CryptoAddress `json:"addresses"`
+		} `json:"data"`
+	}
+
+	if err := e.client().call(ctx, "crypto.addresses.list", http.MethodGet, "crypto/addresses", nil, &responseData); err != nil {
+		return nil, err
+	}
+
+	if responseData.Data.Addresses == nil {
+		return []CryptoAddress{}, nil
+	}
+
+	return responseData.Data.Addresses, nil
+}
+
+// FindAddress function to get a saved address by its address e.g "TJ2..." or its ID.
+// ErrAddressNotFound is returned if no saved address matches.
+func (e *Crypto) FindAddress(address string) (*CryptoAddress, error) {
+	return e.FindAddressContext(context.Background(), address)
+}
+
+// FindAddressContext is like FindAddress but sends the requests with ctx.
+func (e *Crypto) FindAddressContext(ctx context.Context, address string) (*CryptoAddress, error) {
+	addresses, err := e.AddressesContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range addresses {
+		if a.Address == address || a.ID == address {
+			return &a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+}
+
+// SearchAddresses function to find saved addresses matching the given criteria.
+func (e *Crypto) SearchAddresses(query AddressSearch) ([]CryptoAddress, error) {
+	return e.SearchAddressesContext(context.Background(), query)
+}
+
+// SearchAddressesContext is like SearchAddresses but sends the requests with ctx.
+func (e *Crypto) SearchAddressesContext(ctx context.Context, query AddressSearch) ([]CryptoAddress, error) {
+	addresses, err := e.AddressesContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []CryptoAddress{}
+
+	for _, address := range addresses {
+		if query.matches(address) {
+			matches = append(matches, address)
+		}
+	}
+
+	return matches, nil
+}
+
+func (q AddressSearch) matches(a CryptoAddress) bool {
+	if q.AssetID != "" && !strings.EqualFold(q.AssetID, a.AssetID) {
+		return false
+	}
+
+	if q.OwnerName != "" && !strings.Contains(strings.ToLower(a.OwnerName), strings.ToLower(strings.TrimSpace(q.OwnerName))) {
+		return false
+	}
+
+	if q.Purpose != "" && !strings.EqualFold(q.Purpose, a.Purpose) {
+		return false
+	}
+
+	return true
+}
+
+// Transactions function to get a list of crypto transactions. This is used to get the transactions you have made.
+func (e *Crypto) Transactions() ([]CryptoTransaction, error) {
+	return e.TransactionsContext(context.Background())
+}
+
+// TransactionsContext is like Transactions but sends the requests with ctx.
+func (e *Crypto) TransactionsContext(ctx context.Context) ([]CryptoTransaction, error) {
+	return e.transactions(ctx, "crypto.transactions.list", "crypto/transactions")
+}
+
+// AddressTransactions function to get a list of transactions for a specific address. This is used to get the transactions for a specific address.
+// An *InvalidAddressError is returned without calling the API if the address is not in the format of any supported chain.
+func (e *Crypto) AddressTransactions(cryptoCoinAddress string) ([]CryptoTransaction, error) {
+	return e.AddressTransactionsContext(context.Background(), cryptoCoinAddress)
+}
+
+// AddressTransactionsContext is like AddressTransactions but sends the requests with ctx.
+func (e *Crypto) AddressTransactionsContext(ctx context.Context, cryptoCoinAddress string) ([]CryptoTransaction, error) {
+	if err := validateAnyAddress(cryptoCoinAddress); err != nil {
+		return nil, err
+	}
+
+	return e.transactions(ctx, "crypto.addresses.transactions", "crypto/addresses/"+cryptoCoinAddress+"/transactions")
+}
+
+func (e *Crypto) transactions(ctx context.Context, operation string, path string) ([]CryptoTransaction, error) {
+	var responseData struct {
+		Data struct {
+			Transactions []CryptoTransaction `json:"transactions"`
+		} `json:"data"`
+	}
+
+	if err := e.client().call(ctx, operation, http.MethodGet, path, nil, &responseData); err != nil {
+		return nil, err
+	}
+
+	if responseData.Data.Transactions == nil {
+		return []CryptoTransaction{}, nil
+	}
+
+	return responseData.Data.Transactions, nil
+}
+
+// CreateAddress function to create a crypto address. This is used to create a crypto address for a specific coin.
+// The assetId is the id of the asset you want to create the address for. Valid asset from the AssetChains function.
+// The ownerName is the name of the owner of the address.
+// The destinationAddressDescription is the description of the address. Should be Client email or unique identifier.
+// The assetId is checked against the AssetChains of its coin first, the part before "_" e.g "USDT" for "USDT_TRON",
+// and an *InvalidAssetError is returned if it is not one of them. The error of AssetChains is returned if the coin
+// is not supported.
+func (e *Crypto) CreateAddress(assetId string, ownerName string, destinationAddressDescription string, purpose string) (*CryptoAddress, error) {
+	return e.CreateAddressContext(context.Background(), assetId, ownerName, destinationAddressDescription, purpose)
+}
+
+// CreateAddressContext is like CreateAddress but sends the requests with ctx.
+func (e *Crypto) CreateAddressContext(ctx context.Context, assetId string, ownerName string, destinationAddressDescription string, purpose string) (*CryptoAddress, error) {
+	if err := e.validateAsset(ctx, assetId); err != nil {
+		return nil, err
+	}
+
+	var responseData struct {
+		Data CryptoAddress `json:"data"`
+	}
+
+	err := e.client().call(ctx, "crypto.addresses.create", http.MethodPost, "crypto/addresses", map[string]interface{}{
+		"assetId":                       assetId,
+		"ownerName":                     ownerName,
+		"destinationAddressDescription": destinationAddressDescription,
+		"purpose":                       purpose,
+	}, &responseData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseData.Data, nil
+}
+
+// validateAsset checks that the asset id is one of the asset chains of its coin.
+func (e *Crypto) validateAsset(ctx context.Context, assetId string) error {
+	coin, _, _ := strings.Cut(assetId, "_")
+
+	if coin == "" {
+		return &InvalidAssetError{AssetID: assetId}
+	}
+
+	asset, err := e.AssetChainsContext(ctx, coin)
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := asset.FindChain(assetId); ok {
+		return nil
+	}
+
+	invalid := &InvalidAssetError{AssetID: assetId}
+
+	for _, chain := range asset.Chains {
+		invalid.Valid = append(invalid.Valid, chain.AssetID)
+	}
+
+	return invalid
+}
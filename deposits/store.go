@@ -0,0 +1,135 @@
+package deposits
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cetric32/eversend_go_sdk/internal/jsonfile"
+)
+
+// AddressState is what the watcher remembers about the deposits of an address.
+type AddressState struct {
+	// LastSeen is the ID of the newest transaction of the address the watcher has seen.
+	LastSeen string `json:"lastSeen,omitempty"`
+	// Pending holds the confirmations of the deposits seen but not confirmed yet, by transaction ID.
+	Pending map[string]int `json:"pending,omitempty"`
+	// Confirmed holds the IDs of the last deposits confirmed, so that a late webhook event does not report them again.
+	Confirmed []string `json:"confirmed,omitempty"`
+}
+
+func (s *AddressState) confirmed(transactionID string) bool {
+	for _, id := range s.Confirmed {
+		if id == transactionID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Store keeps the state of each watched address so that a restarted watcher does not emit the same events again.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns the state of the address, an empty state if it has none.
+	Load(ctx context.Context, address string) (*AddressState, error)
+	// Save replaces the state of the address.
+	Save(ctx context.Context, address string, state *AddressState) error
+}
+
+// MemoryStore is a Store that keeps the states in memory. They are lost when the process exits.
+type MemoryStore struct {
+	mutex  sync.Mutex
+	states map[string]*AddressState
+
+	// persist saves the states after Save changed them, with the mutex held. FileStore sets it to write its file.
+	persist func() error
+}
+
+// NewMemoryStore function to create an in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: map[string]*AddressState{}}
+}
+
+// Load implements Store.
+func (s *MemoryStore) Load(ctx context.Context, address string) (*AddressState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyState(s.states[address]), nil
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(ctx context.Context, address string, state *AddressState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, existed := s.states[address]
+	s.states[address] = copyState(state)
+
+	if s.persist == nil {
+		return nil
+	}
+
+	if err := s.persist(); err != nil {
+		// keep the memory in line with the file
+		if existed {
+			s.states[address] = previous
+		} else {
+			delete(s.states, address)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func copyState(state *AddressState) *AddressState {
+	copied := &AddressState{Pending: map[string]int{}}
+
+	if state == nil {
+		return copied
+	}
+
+	copied.LastSeen = state.LastSeen
+	copied.Confirmed = append([]string(nil), state.Confirmed...)
+
+	for id, confirmations := range state.Pending {
+		copied.Pending[id] = confirmations
+	}
+
+	return copied
+}
+
+// FileStore is a Store that keeps the states in a JSON file so that they survive restarts.
+// The file is rewritten after every change. Only one process may use a file at a time.
+type FileStore struct {
+	*MemoryStore
+
+	path string
+}
+
+// NewFileStore function to open, or create, a file backed Store at path.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+	}
+
+	if err := jsonfile.Read(path, &store.states); err != nil {
+		return nil, err
+	}
+
+	if store.states == nil {
+		store.states = map[string]*AddressState{}
+	}
+
+	store.persist = store.write
+
+	return store, nil
+}
+
+// write saves the states to the file.
+func (s *FileStore) write() error {
+	return jsonfile.Write(s.path, s.states)
+}
@@ -0,0 +1,377 @@
+// Package deposits watches crypto deposit addresses and reports the deposits made to them.
+//
+//	watcher := deposits.New(app.Crypto, deposits.NewMemoryStore(), deposits.WithInterval(time.Minute))
+//	watcher.Watch(address.Address)
+//
+//	go watcher.Run(ctx)
+//
+//	for event := range watcher.Events() {
+//		if event.Confirmed {
+//			// credit the customer
+//		}
+//	}
+//
+// The watcher polls Crypto.AddressTransactions for every watched address. When webhooks are set up, HandleEvent
+// can also be registered for the crypto.deposit event so deposits are reported as soon as they arrive:
+//
+//	handler.On(webhooks.EventCryptoDeposit, watcher.HandleEvent)
+//
+// An event is emitted when a deposit is first seen and again each time its number of confirmations changes,
+// until it is confirmed. The Store remembers the last transaction seen, the pending deposits and the last confirmed
+// deposits of each address, and is only updated after the events were delivered, so events may be delivered again after a crash but are not lost.
+package deposits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/webhooks"
+)
+
+// Source tells how a deposit was observed.
+type Source string
+
+const (
+	SourcePoll    Source = "poll"
+	SourceWebhook Source = "webhook"
+)
+
+// Event is a deposit to a watched address, or a change of its confirmations.
+type Event struct {
+	Address       string  `json:"address"`
+	TransactionID string  `json:"transactionId"`
+	AssetID       string  `json:"assetId"`
+	Coin          string  `json:"coin"`
+	Chain         string  `json:"chain"`
+	Amount        float64 `json:"amount"`
+	TxHash        string  `json:"txHash"`
+	Status        string  `json:"status"`
+	Confirmations int     `json:"confirmations"`
+	// Confirmed is set once the deposit is completed, or has the confirmations set with WithConfirmations.
+	// No more events are emitted for a confirmed deposit.
+	Confirmed bool `json:"confirmed"`
+	// New is set on the first event of a deposit.
+	New        bool      `json:"new"`
+	Source     Source    `json:"source"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+// Watcher reports the deposits made to a set of addresses.
+type Watcher struct {
+	crypto        eversendSdk.CryptoService
+	store         Store
+	interval      time.Duration
+	confirmations int
+	onError       func(address string, err error)
+	events        chan Event
+	now           func() time.Time
+
+	mutex     sync.Mutex
+	addresses map[string]bool
+	// processing serialises the updates of the store by polls and webhook events
+	processing sync.Mutex
+}
+
+// Option is an optional setting passed to New.
+type Option func(w *Watcher)
+
+// WithInterval sets how often the addresses are polled. The default is 30 seconds.
+func WithInterval(interval time.Duration) Option {
+	return func(w *Watcher) {
+		w.interval = interval
+	}
+}
+
+// WithConfirmations sets the confirmations after which a deposit is confirmed, even if its status is still pending.
+// By default a deposit is confirmed when Eversend marks it completed.
+func WithConfirmations(confirmations int) Option {
+	return func(w *Watcher) {
+		w.confirmations = confirmations
+	}
+}
+
+// WithBuffer sets the size of the buffer of the Events channel. The default is 64.
+func WithBuffer(size int) Option {
+	return func(w *Watcher) {
+		w.events = make(chan Event, size)
+	}
+}
+
+// WithErrorHandler sets a function called by Run when polling an address fails. Run keeps polling.
+func WithErrorHandler(fn func(address string, err error)) Option {
+	return func(w *Watcher) {
+		w.onError = fn
+	}
+}
+
+// New function to create a Watcher polling with the CryptoService e.g app.Crypto and remembering what it saw in the store.
+func New(crypto eversendSdk.CryptoService, store Store, opts ...Option) *Watcher {
+	w := &Watcher{
+		crypto:    crypto,
+		store:     store,
+		interval:  30 * time.Second,
+		events:    make(chan Event, 64),
+		now:       time.Now,
+		addresses: map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Events returns the channel the events are delivered on. It must be read, as polls and HandleEvent wait for
+// room on the channel. It is never closed.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Watch adds addresses to watch e.g the Address of a CryptoAddress returned by Crypto.CreateAddress.
+func (w *Watcher) Watch(addresses ...string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, address := range addresses {
+		w.addresses[address] = true
+	}
+}
+
+// Unwatch stops watching an address. Its state is kept in the store.
+func (w *Watcher) Unwatch(address string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.addresses, address)
+}
+
+// Addresses returns the watched addresses, sorted.
+func (w *Watcher) Addresses() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	addresses := make([]string, 0, len(w.addresses))
+
+	for address := range w.addresses {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+func (w *Watcher) watching(address string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.addresses[address]
+}
+
+// Run polls the addresses every interval until the context is done, and returns its error.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, address := range w.Addresses() {
+			if err := w.pollAddress(ctx, address); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				if w.onError != nil {
+					w.onError(address, err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll polls every watched address once. The errors of the addresses that failed are joined.
+func (w *Watcher) Poll(ctx context.Context) error {
+	errs := []error{}
+
+	for _, address := range w.Addresses() {
+		if err := w.pollAddress(ctx, address); err != nil {
+			errs = append(errs, fmt.Errorf("deposits: %s: %w", address, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *Watcher) pollAddress(ctx context.Context, address string) error {
+	transactions, err := w.crypto.AddressTransactionsContext(ctx, address)
+
+	if err != nil {
+		return err
+	}
+
+	// the API lists the transactions oldest first, the sort only guards against another order
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt < transactions[j].CreatedAt
+	})
+
+	w.processing.Lock()
+	defer w.processing.Unlock()
+
+	state, err := w.store.Load(ctx, address)
+
+	if err != nil {
+		return err
+	}
+
+	// the transactions up to the last seen one are old, unless the last seen one is not listed any more
+	seen := map[string]bool{}
+
+	for _, transaction := range transactions {
+		if state.LastSeen == "" {
+			break
+		}
+
+		seen[transaction.ID] = true
+
+		if transaction.ID == state.LastSeen {
+			break
+		}
+	}
+
+	if !seen[state.LastSeen] {
+		seen = map[string]bool{}
+	}
+
+	events := []Event{}
+
+	for _, transaction := range transactions {
+		if !isDeposit(transaction.Type) {
+			continue
+		}
+
+		event := Event{
+			Address:       address,
+			TransactionID: transaction.ID,
+			AssetID:       transaction.AssetID,
+			Coin:          transaction.Coin,
+			Chain:         transaction.Chain,
+			Amount:        transaction.Amount,
+			TxHash:        transaction.TxHash,
+			Status:        transaction.Status,
+			Confirmations: transaction.Confirmations,
+			Source:        SourcePoll,
+		}
+
+		if w.update(state, &event, seen[transaction.ID] || state.confirmed(transaction.ID)) {
+			events = append(events, event)
+		}
+	}
+
+	if len(transactions) > 0 {
+		state.LastSeen = transactions[len(transactions)-1].ID
+	}
+
+	return w.deliver(ctx, address, state, events)
+}
+
+// HandleEvent is a webhooks.HandlerFunc for the crypto.deposit event. Events for addresses that are not watched
+// are ignored.
+func (w *Watcher) HandleEvent(ctx context.Context, event *webhooks.Event) error {
+	deposit, ok := event.Payload.(*webhooks.CryptoDepositEvent)
+
+	if !ok || !w.watching(deposit.Address) {
+		return nil
+	}
+
+	w.processing.Lock()
+	defer w.processing.Unlock()
+
+	state, err := w.store.Load(ctx, deposit.Address)
+
+	if err != nil {
+		return err
+	}
+
+	e := Event{
+		Address:       deposit.Address,
+		TransactionID: deposit.TransactionID,
+		AssetID:       deposit.AssetID,
+		Coin:          deposit.Coin,
+		Chain:         deposit.Chain,
+		Amount:        deposit.Amount,
+		TxHash:        deposit.TxHash,
+		Status:        deposit.Status,
+		Confirmations: deposit.Confirmations,
+		Source:        SourceWebhook,
+	}
+
+	if !w.update(state, &e, state.confirmed(deposit.TransactionID)) {
+		return nil
+	}
+
+	return w.deliver(ctx, deposit.Address, state, []Event{e})
+}
+
+// update records the deposit in the state and reports whether an event must be emitted for it.
+// A deposit already seen that is not pending any more was confirmed before.
+func (w *Watcher) update(state *AddressState, event *Event, seen bool) bool {
+	previous, pending := state.Pending[event.TransactionID]
+
+	if seen && !pending {
+		return false
+	}
+
+	if pending && previous == event.Confirmations {
+		return false
+	}
+
+	event.New = !pending
+	event.Confirmed = strings.EqualFold(event.Status, "completed") ||
+		(w.confirmations > 0 && event.Confirmations >= w.confirmations)
+	event.ObservedAt = w.now()
+
+	if event.Confirmed {
+		delete(state.Pending, event.TransactionID)
+		state.Confirmed = append(state.Confirmed, event.TransactionID)
+
+		if len(state.Confirmed) > maxConfirmed {
+			state.Confirmed = state.Confirmed[len(state.Confirmed)-maxConfirmed:]
+		}
+	} else {
+		state.Pending[event.TransactionID] = event.Confirmations
+	}
+
+	return true
+}
+
+// deliver sends the events, then saves the state so that events are not lost if the context ends first.
+func (w *Watcher) deliver(ctx context.Context, address string, state *AddressState, events []Event) error {
+	for _, event := range events {
+		select {
+		case w.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return w.store.Save(ctx, address, state)
+}
+
+// maxConfirmed is the number of confirmed deposits remembered per address.
+const maxConfirmed = 100
+
+func isDeposit(transactionType string) bool {
+	return transactionType == "" || strings.EqualFold(transactionType, "deposit")
+}
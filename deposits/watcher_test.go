@@ -0,0 +1,180 @@
+package deposits
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+	"github.com/cetric32/eversend_go_sdk/webhooks"
+)
+
+func newTestWatcher(t *testing.T, store Store) (*Watcher, *eversendtest.Server, string) {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()))
+	address, err := app.Crypto.CreateAddress("USDT_TRON", "Jane Doe", "jane@example.com", "deposits")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := New(app.Crypto, store)
+	watcher.Watch(address.Address)
+
+	return watcher, srv, address.Address
+}
+
+// drain returns the events delivered so far.
+func drain(w *Watcher) []Event {
+	events := []Event{}
+
+	for {
+		select {
+		case event := <-w.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func poll(t *testing.T, w *Watcher) []Event {
+	t.Helper()
+
+	if err := w.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return drain(w)
+}
+
+func TestWatcherReportsConfirmations(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "deposits.json"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, srv, address := newTestWatcher(t, store)
+
+	if events := poll(t, watcher); len(events) != 0 {
+		t.Fatalf("got %+v before any deposit", events)
+	}
+
+	id := srv.AddCryptoDeposit(address, 25, 3)
+	events := poll(t, watcher)
+
+	if len(events) != 1 || !events[0].New || events[0].Confirmed || events[0].Confirmations != 3 ||
+		events[0].TransactionID != id || events[0].Amount != 25 || events[0].Source != SourcePoll {
+		t.Fatalf("got %+v, want a new pending deposit", events)
+	}
+
+	if events := poll(t, watcher); len(events) != 0 {
+		t.Fatalf("got %+v without any change", events)
+	}
+
+	srv.SetCryptoConfirmations(id, 6)
+
+	if events := poll(t, watcher); len(events) != 1 || events[0].New || events[0].Confirmations != 6 {
+		t.Fatalf("got %+v, want the new confirmations", events)
+	}
+
+	srv.SetCryptoConfirmations(id, eversendtest.RequiredConfirmations)
+
+	if events := poll(t, watcher); len(events) != 1 || !events[0].Confirmed || events[0].Status != "completed" {
+		t.Fatalf("got %+v, want the deposit confirmed", events)
+	}
+
+	second := srv.AddCryptoDeposit(address, 10, 0)
+
+	// a new watcher on the same file only reports what it has not seen
+	reopened, err := NewFileStore(store.path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := New(watcher.crypto, reopened)
+	restarted.Watch(address)
+
+	if events := poll(t, restarted); len(events) != 1 || events[0].TransactionID != second {
+		t.Fatalf("got %+v after a restart, want only the second deposit", events)
+	}
+}
+
+func TestWatcherConfirmationsOption(t *testing.T) {
+	watcher, srv, address := newTestWatcher(t, NewMemoryStore())
+	watcher.confirmations = 2
+
+	id := srv.AddCryptoDeposit(address, 25, 2)
+
+	if events := poll(t, watcher); len(events) != 1 || !events[0].Confirmed || events[0].Status != "pending" {
+		t.Fatalf("got %+v, want the deposit confirmed after 2 confirmations", events)
+	}
+
+	srv.SetCryptoConfirmations(id, 5)
+
+	if events := poll(t, watcher); len(events) != 0 {
+		t.Fatalf("got %+v for a confirmed deposit", events)
+	}
+}
+
+func TestWatcherHandleEvent(t *testing.T) {
+	watcher, srv, address := newTestWatcher(t, NewMemoryStore())
+	ctx := context.Background()
+
+	id := srv.AddCryptoDeposit(address, 25, eversendtest.RequiredConfirmations)
+	deposit := &webhooks.CryptoDepositEvent{TransactionID: id, Status: "completed", Address: address, Amount: 25,
+		Confirmations: eversendtest.RequiredConfirmations}
+
+	if err := watcher.HandleEvent(ctx, &webhooks.Event{Type: webhooks.EventCryptoDeposit, Payload: deposit}); err != nil {
+		t.Fatal(err)
+	}
+
+	if events := drain(watcher); len(events) != 1 || !events[0].New || !events[0].Confirmed || events[0].Source != SourceWebhook {
+		t.Fatalf("got %+v, want the deposit of the webhook", events)
+	}
+
+	// the poll and a late delivery of the webhook do not report it again
+	if events := poll(t, watcher); len(events) != 0 {
+		t.Fatalf("got %+v from the poll of a deposit reported by webhook", events)
+	}
+
+	if err := watcher.HandleEvent(ctx, &webhooks.Event{Type: webhooks.EventCryptoDeposit, Payload: deposit}); err != nil {
+		t.Fatal(err)
+	}
+
+	other := &webhooks.CryptoDepositEvent{TransactionID: "ctx_other", Status: "pending", Address: "TJ2other", Amount: 1}
+
+	if err := watcher.HandleEvent(ctx, &webhooks.Event{Type: webhooks.EventCryptoDeposit, Payload: other}); err != nil {
+		t.Fatal(err)
+	}
+
+	if events := drain(watcher); len(events) != 0 {
+		t.Fatalf("got %+v for a duplicate and an unwatched address", events)
+	}
+}
+
+func TestWatcherKeepsStateUntilDelivered(t *testing.T) {
+	store := NewMemoryStore()
+	watcher, srv, address := newTestWatcher(t, store)
+	watcher.events = make(chan Event)
+
+	srv.AddCryptoDeposit(address, 25, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := watcher.Poll(ctx); err == nil {
+		t.Fatal("got no error polling with nobody reading the events")
+	}
+
+	if state, _ := store.Load(context.Background(), address); len(state.Pending) != 0 || state.LastSeen != "" {
+		t.Fatalf("got state %+v saved before the events were delivered", state)
+	}
+}
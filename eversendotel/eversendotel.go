@@ -0,0 +1,246 @@
+// Package eversendotel instruments the Eversend SDK with OpenTelemetry traces and metrics.
+// It is a separate module, so that the SDK does not depend on OpenTelemetry:
+//
+//	go get github.com/cetric32/eversend_go_sdk/eversendotel
+//
+//	app := eversendSdk.NewEversendApp(clientId, clientSecret, eversendSdk.WithMiddleware(eversendotel.Middleware()))
+//	wallets, err := app.Wallets.ListContext(ctx)
+//
+// Every request made by the SDK gets a client span named after its operation e.g "eversend.payouts.create",
+// and is counted in the metrics below. The spans are children of the span in the context passed to the
+// ...Context methods. Put the middleware first so that it also measures the other middlewares.
+// A request sent again by the SDK, e.g with a new token after a 401, gets a span of its own with its attempt.
+// Put the middleware after a RateLimiter to also get a span for each retry of a 429 response.
+//
+//   - eversend.client.requests: the number of requests
+//   - eversend.client.request.duration: the duration of requests in seconds
+//   - eversend.client.token.refreshes: the number of auth token requests
+//   - eversend.client.errors: the number of failed requests by error type
+//
+// The module requires a published version of the SDK. To work on both at once, use a workspace from the root of the
+// repository, which is not committed:
+//
+//	go work init . ./eversendotel
+//
+// Spans and metrics are PII-safe. They carry the operation, method, status code, attempt and error type only,
+// never the URL path, the request or response bodies, or error messages, which can hold phone numbers,
+// account numbers, addresses or tokens.
+package eversendotel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/cetric32/eversend_go_sdk/eversendotel"
+
+// The attributes set on spans and metrics.
+const (
+	OperationKey  = attribute.Key("eversend.operation")
+	AttemptKey    = attribute.Key("eversend.attempt")
+	RetryKey      = attribute.Key("eversend.retry")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	ErrorTypeKey  = attribute.Key("error.type")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option is an optional setting passed to Middleware.
+type Option func(c *config)
+
+// WithTracerProvider sets the TracerProvider used to create spans. The default is the global one.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to create the metrics. The default is the global one.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators that inject the span context into the request headers. The default is the global one.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+type instruments struct {
+	tracer    trace.Tracer
+	requests  metric.Int64Counter
+	duration  metric.Float64Histogram
+	refreshes metric.Int64Counter
+	errors    metric.Int64Counter
+}
+
+// Middleware returns an eversendSdk.Middleware that traces and measures every request.
+// It panics if the metrics cannot be created, which only happens with an invalid MeterProvider.
+func Middleware(opts ...Option) eversendSdk.Middleware {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	i, err := newInstruments(c)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return func(next eversendSdk.RoundTripFunc) eversendSdk.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return i.roundTrip(c.propagators, next, req)
+		}
+	}
+}
+
+func newInstruments(c config) (*instruments, error) {
+	meter := c.meterProvider.Meter(ScopeName)
+
+	requests, err := meter.Int64Counter("eversend.client.requests",
+		metric.WithDescription("Number of requests sent to the Eversend API."),
+		metric.WithUnit("{request}"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("eversend.client.request.duration",
+		metric.WithDescription("Duration of requests sent to the Eversend API."),
+		metric.WithUnit("s"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	refreshes, err := meter.Int64Counter("eversend.client.token.refreshes",
+		metric.WithDescription("Number of auth token requests."),
+		metric.WithUnit("{refresh}"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	errorCount, err := meter.Int64Counter("eversend.client.errors",
+		metric.WithDescription("Number of requests that failed or got a status code other than 200."),
+		metric.WithUnit("{error}"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &instruments{
+		tracer:    c.tracerProvider.Tracer(ScopeName),
+		requests:  requests,
+		duration:  duration,
+		refreshes: refreshes,
+		errors:    errorCount,
+	}, nil
+}
+
+func (i *instruments) roundTrip(propagators propagation.TextMapPropagator, next eversendSdk.RoundTripFunc, req *http.Request) (*http.Response, error) {
+	operation := eversendSdk.Operation(req.Context())
+	attempt := eversendSdk.Attempt(req.Context())
+
+	attributes := []attribute.KeyValue{
+		OperationKey.String(operation),
+		MethodKey.String(req.Method),
+	}
+
+	ctx, span := i.tracer.Start(req.Context(), "eversend."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+		trace.WithAttributes(AttemptKey.Int(attempt), RetryKey.Bool(attempt > 1)))
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	resp, err := next(req)
+	elapsed := time.Since(start).Seconds()
+
+	errorType := ""
+
+	if err != nil {
+		errorType = ErrorType(err)
+	} else {
+		attributes = append(attributes, StatusCodeKey.Int(resp.StatusCode))
+		span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+
+		if resp.StatusCode != http.StatusOK {
+			errorType = strconv.Itoa(resp.StatusCode)
+		}
+	}
+
+	if errorType != "" {
+		attributes = append(attributes, ErrorTypeKey.String(errorType))
+		span.SetAttributes(ErrorTypeKey.String(errorType))
+		// the error message is not recorded because it can hold personal data
+		span.SetStatus(codes.Error, errorType)
+		i.errors.Add(ctx, 1, metric.WithAttributes(attributes...))
+	}
+
+	measured := metric.WithAttributes(attributes...)
+
+	i.requests.Add(ctx, 1, measured)
+	i.duration.Record(ctx, elapsed, measured)
+
+	if operation == "auth.token" {
+		i.refreshes.Add(ctx, 1, measured)
+	}
+
+	return resp, err
+}
+
+// ErrorType returns the low-cardinality type of a request error used for the error.type attribute:
+// "circuit_open", "rate_limited", "timeout", "canceled", "network" or, for any other error, "other".
+func ErrorType(err error) string {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, eversendSdk.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, eversendSdk.ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return "timeout"
+		}
+
+		return "network"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,217 @@
+package eversendotel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newInstrumentedApp(t *testing.T) (*eversendSdk.Eversend, *eversendtest.Server, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	middleware := Middleware(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithPropagators(propagation.TraceContext{}),
+	)
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret,
+		eversendSdk.WithBaseUrl(srv.BaseURL()), eversendSdk.WithMiddleware(middleware))
+
+	return app, srv, spans, reader
+}
+
+func TestSpans(t *testing.T) {
+	app, srv, spans, _ := newInstrumentedApp(t)
+
+	if _, err := app.Payouts.MomoPayout("bad-token", "+256712345678", "Jane", "Doe", "UG"); err == nil {
+		t.Fatal("got no error for an unknown payout token")
+	}
+
+	ended := spans.Ended()
+
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want the token and payout spans", len(ended))
+	}
+
+	if ended[0].Name() != "eversend.auth.token" || ended[1].Name() != "eversend.payouts.create" {
+		t.Fatalf("got spans %s and %s", ended[0].Name(), ended[1].Name())
+	}
+
+	payout := ended[1]
+	attributes := attribute.NewSet(payout.Attributes()...)
+
+	if value, _ := attributes.Value(StatusCodeKey); value.AsInt64() == 200 {
+		t.Fatalf("got status code %d on a failed payout", value.AsInt64())
+	}
+
+	if value, _ := attributes.Value(AttemptKey); value.AsInt64() != 1 {
+		t.Fatalf("got attempt %d, want 1", value.AsInt64())
+	}
+
+	if payout.Status().Code != codes.Error {
+		t.Fatalf("got span status %v, want an error", payout.Status().Code)
+	}
+
+	for _, kv := range payout.Attributes() {
+		if strings.Contains(kv.Value.Emit(), "256712345678") || strings.Contains(kv.Value.Emit(), "bad-token") {
+			t.Fatalf("attribute %s holds personal data: %s", kv.Key, kv.Value.Emit())
+		}
+	}
+
+	for _, request := range srv.Requests() {
+		if request.Header.Get("Traceparent") == "" {
+			t.Fatalf("%s %s was sent without the trace context", request.Method, request.Path)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	app, _, _, reader := newInstrumentedApp(t)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Wallets.Find("XXX"); err == nil {
+		t.Fatal("got no error for an unknown wallet")
+	}
+
+	data := metricdata.ResourceMetrics{}
+
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	sums := map[string]int64{}
+	histograms := map[string]uint64{}
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch d := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range d.DataPoints {
+					sums[m.Name] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range d.DataPoints {
+					histograms[m.Name] += point.Count
+				}
+			}
+		}
+	}
+
+	if sums["eversend.client.requests"] != 3 {
+		t.Errorf("got %d requests, want 3", sums["eversend.client.requests"])
+	}
+
+	if sums["eversend.client.token.refreshes"] != 1 {
+		t.Errorf("got %d token refreshes, want 1", sums["eversend.client.token.refreshes"])
+	}
+
+	if sums["eversend.client.errors"] != 1 {
+		t.Errorf("got %d errors, want 1", sums["eversend.client.errors"])
+	}
+
+	if histograms["eversend.client.request.duration"] != 3 {
+		t.Errorf("got %d durations, want 3", histograms["eversend.client.request.duration"])
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	if got := ErrorType(context.DeadlineExceeded); got != "timeout" {
+		t.Errorf("got %s for a deadline, want timeout", got)
+	}
+
+	if got := ErrorType(context.Canceled); got != "canceled" {
+		t.Errorf("got %s for a cancelation, want canceled", got)
+	}
+
+	if got := ErrorType(fmt.Errorf("%w: payouts.create", eversendSdk.ErrCircuitOpen)); got != "circuit_open" {
+		t.Errorf("got %s for an open circuit, want circuit_open", got)
+	}
+}
+
+func TestSpansJoinCallerTrace(t *testing.T) {
+	app, _, spans, _ := newInstrumentedApp(t)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x04, 0x05},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+
+	if _, err := app.Wallets.ListContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ended := spans.Ended()
+
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want the token and wallets spans", len(ended))
+	}
+
+	for _, span := range ended {
+		if span.Parent().SpanID() != parent.SpanID() || span.SpanContext().TraceID() != parent.TraceID() {
+			t.Fatalf("span %s is not a child of the caller's span", span.Name())
+		}
+	}
+}
+
+func TestRetriedRequestAttempt(t *testing.T) {
+	app, srv, spans, _ := newInstrumentedApp(t)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the revoked token is rejected and the request is sent again with a new one
+	srv.ExpireTokens()
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := []int64{}
+
+	for _, span := range spans.Ended() {
+		if span.Name() != "eversend.wallets.list" {
+			continue
+		}
+
+		attributes := attribute.NewSet(span.Attributes()...)
+		attempt, _ := attributes.Value(AttemptKey)
+		retry, _ := attributes.Value(RetryKey)
+
+		if retry.AsBool() != (attempt.AsInt64() > 1) {
+			t.Fatalf("got retry %v on attempt %d", retry.AsBool(), attempt.AsInt64())
+		}
+
+		attempts = append(attempts, attempt.AsInt64())
+	}
+
+	if fmt.Sprint(attempts) != "[1 1 2]" {
+		t.Fatalf("got attempts %v, want [1 1 2]", attempts)
+	}
+}
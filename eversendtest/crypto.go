@@ -0,0 +1,237 @@
+package eversendtest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// RequiredConfirmations is the number of confirmations after which the fake marks a crypto deposit completed.
+const RequiredConfirmations = 12
+
+type cryptoChain struct {
+	AssetID string `json:"assetId"`
+	Chain   string `json:"chain"`
+	Name    string `json:"name"`
+}
+
+type cryptoAddress struct {
+	ID                            string    `json:"id"`
+	Address                       string    `json:"address"`
+	AssetID                       string    `json:"assetId"`
+	Coin                          string    `json:"coin"`
+	Chain                         string    `json:"chain"`
+	OwnerName                     string    `json:"ownerName"`
+	DestinationAddressDescription string    `json:"destinationAddressDescription"`
+	Purpose                       string    `json:"purpose"`
+	CreatedAt                     time.Time `json:"createdAt"`
+}
+
+type cryptoTransaction struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	Address       string    `json:"address"`
+	AssetID       string    `json:"assetId"`
+	Coin          string    `json:"coin"`
+	Chain         string    `json:"chain"`
+	Amount        float64   `json:"amount"`
+	TxHash        string    `json:"txHash"`
+	Confirmations int       `json:"confirmations"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+var assetChains = map[string][]cryptoChain{
+	"USDT": {
+		{AssetID: "USDT_TRON", Chain: "TRON", Name: "Tether USD (TRC20)"},
+		{AssetID: "USDT_ETH", Chain: "ETH", Name: "Tether USD (ERC20)"},
+		{AssetID: "USDT_BSC", Chain: "BSC", Name: "Tether USD (BEP20)"},
+	},
+	"USDC": {
+		{AssetID: "USDC_ETH", Chain: "ETH", Name: "USD Coin (ERC20)"},
+		{AssetID: "USDC_SOL", Chain: "SOL", Name: "USD Coin (Solana)"},
+	},
+	"BTC": {
+		{AssetID: "BTC", Chain: "BTC", Name: "Bitcoin"},
+	},
+}
+
+// AddCryptoDeposit records an incoming deposit to an address created through the fake and returns its transaction id.
+// The deposit is pending until it has RequiredConfirmations confirmations.
+func (s *Server) AddCryptoDeposit(address string, amount float64, confirmations int) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	transaction := &cryptoTransaction{
+		ID:        newID("ctx_"),
+		Type:      "deposit",
+		Address:   address,
+		Amount:    amount,
+		TxHash:    randomHex(32),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, a := range s.addresses {
+		if a.Address == address {
+			transaction.AssetID, transaction.Coin, transaction.Chain = a.AssetID, a.Coin, a.Chain
+		}
+	}
+
+	transaction.setConfirmations(confirmations)
+	s.cryptoTransactions = append(s.cryptoTransactions, transaction)
+
+	return transaction.ID
+}
+
+// SetCryptoConfirmations changes the number of confirmations of a deposit added with AddCryptoDeposit.
+func (s *Server) SetCryptoConfirmations(transactionID string, confirmations int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, transaction := range s.cryptoTransactions {
+		if transaction.ID == transactionID {
+			transaction.setConfirmations(confirmations)
+		}
+	}
+}
+
+func (t *cryptoTransaction) setConfirmations(confirmations int) {
+	t.Confirmations = confirmations
+	t.Status = "pending"
+
+	if confirmations >= RequiredConfirmations {
+		t.Status = "completed"
+	}
+}
+
+func (s *Server) handleAssetChains(w http.ResponseWriter, coin string) {
+	chains, ok := assetChains[coin]
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Coin not supported")
+		return
+	}
+
+	writeData(w, map[string]interface{}{
+		"coin":   coin,
+		"chains": chains,
+	})
+}
+
+func (s *Server) handleCreateAddress(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		AssetID                       string `json:"assetId"`
+		OwnerName                     string `json:"ownerName"`
+		DestinationAddressDescription string `json:"destinationAddressDescription"`
+		Purpose                       string `json:"purpose"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	for coin, chains := range assetChains {
+		for _, chain := range chains {
+			if chain.AssetID != body.AssetID {
+				continue
+			}
+
+			address := &cryptoAddress{
+				ID:                            newID("addr_"),
+				Address:                       newAddress(chain.Chain),
+				AssetID:                       chain.AssetID,
+				Coin:                          coin,
+				Chain:                         chain.Chain,
+				OwnerName:                     body.OwnerName,
+				DestinationAddressDescription: body.DestinationAddressDescription,
+				Purpose:                       body.Purpose,
+				CreatedAt:                     time.Now().UTC(),
+			}
+			s.addresses = append(s.addresses, address)
+
+			writeData(w, address)
+			return
+		}
+	}
+
+	writeError(w, http.StatusBadRequest, "Invalid assetId")
+}
+
+func (s *Server) handleAddressTransactions(w http.ResponseWriter, address string) {
+	transactions := []*cryptoTransaction{}
+
+	for _, transaction := range s.cryptoTransactions {
+		if transaction.Address == address {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	writeData(w, map[string]interface{}{"transactions": transactions})
+}
+
+// newAddress returns a random address in the format of the chain.
+func newAddress(chain string) string {
+	switch chain {
+	case "TRON":
+		return base58Check(0x41, randomBytes(20))
+	case "BTC":
+		return base58Check(0x00, randomBytes(20))
+	case "SOL":
+		return base58(randomBytes(32))
+	default:
+		return "0x" + hex.EncodeToString(randomBytes(20))
+	}
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Check(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+
+	return base58(append(data, second[:4]...))
+}
+
+func base58(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	encoded := []byte{}
+
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func randomHex(n int) string {
+	return hex.EncodeToString(randomBytes(n))
+}
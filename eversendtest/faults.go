@@ -0,0 +1,118 @@
+package eversendtest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FaultKind is the way a Fault fails a request.
+type FaultKind int
+
+const (
+	// FaultTimeout stalls the request for the Delay of the Fault, or until the client gives up, then responds with a 504.
+	FaultTimeout FaultKind = iota
+	// FaultServerError responds with a 500.
+	FaultServerError
+	// FaultInsufficientFunds responds with the 400 Eversend sends when a wallet balance is too low.
+	FaultInsufficientFunds
+	// FaultMalformedResponse responds with a 200 whose body is not valid JSON.
+	FaultMalformedResponse
+	// FaultRateLimited responds with a 429 whose Retry-After header is the Delay of the Fault in seconds, rounded up.
+	FaultRateLimited
+)
+
+// defaultTimeoutDelay is how long a FaultTimeout stalls when the Fault has no Delay.
+const defaultTimeoutDelay = 30 * time.Second
+
+// Fault makes the fake fail the requests it matches.
+type Fault struct {
+	Kind FaultKind
+	// Method matches the request method e.g "POST". Empty matches every method.
+	Method string
+	// Path matches requests whose path, relative to the base URL, starts with it e.g "payouts". Empty matches every path.
+	Path string
+	// Delay is how long a FaultTimeout stalls, or the Retry-After of a FaultRateLimited. The default is 30 seconds for a FaultTimeout.
+	Delay time.Duration
+	// Times is the number of requests to fail. Zero fails every matching request until ClearFaults is called.
+	Times int
+}
+
+// InjectFault makes the fake fail the requests matching the fault. Faults are matched in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.faults = nil
+}
+
+// matchFault returns the first fault matching the request and uses up one of its Times. It is called with the mutex held.
+func (s *Server) matchFault(method string, path string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+
+		if !strings.HasPrefix(path, fault.Path) {
+			continue
+		}
+
+		matched := *fault
+
+		if fault.Times > 0 {
+			fault.Times--
+
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return &matched
+	}
+
+	return nil
+}
+
+func (s *Server) serveFault(w http.ResponseWriter, r *http.Request, fault *Fault) {
+	switch fault.Kind {
+	case FaultTimeout:
+		delay := fault.Delay
+
+		if delay == 0 {
+			delay = defaultTimeoutDelay
+		}
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+
+		writeError(w, http.StatusGatewayTimeout, "Gateway Timeout")
+	case FaultServerError:
+		writeError(w, http.StatusInternalServerError, "Internal Server Error")
+	case FaultInsufficientFunds:
+		writeError(w, http.StatusBadRequest, "Insufficient balance")
+	case FaultMalformedResponse:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": `))
+	case FaultRateLimited:
+		seconds := int((fault.Delay + time.Second - 1) / time.Second)
+
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		writeError(w, http.StatusTooManyRequests, "Too Many Requests")
+	}
+}
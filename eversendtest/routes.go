@@ -0,0 +1,711 @@
+package eversendtest
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type quotation struct {
+	kind               string
+	used               bool
+	from               string
+	to                 string
+	amount             float64
+	destinationAmount  float64
+	rate               float64
+	fees               float64
+	payoutType         string
+	destinationCountry string
+}
+
+type beneficiary struct {
+	ID                int    `json:"id"`
+	FirstName         string `json:"firstName"`
+	LastName          string `json:"lastName"`
+	Email             string `json:"email"`
+	PhoneNumber       string `json:"phoneNumber"`
+	Country           string `json:"country"`
+	BankName          string `json:"bankName"`
+	BankCode          string `json:"bankCode"`
+	BankAccountName   string `json:"bankAccountName"`
+	BankAccountNumber string `json:"bankAccountNumber"`
+	IsBank            bool   `json:"isBank"`
+	IsMomo            bool   `json:"isMomo"`
+}
+
+var deliveryCountries = []map[string]interface{}{
+	{"id": "UG", "name": "Uganda", "phonePrefix": "+256", "currencies": []string{"UGX"}, "paymentTypes": []string{"momo", "bank"}},
+	{"id": "KE", "name": "Kenya", "phonePrefix": "+254", "currencies": []string{"KES"}, "paymentTypes": []string{"momo", "bank"}},
+	{"id": "NG", "name": "Nigeria", "phonePrefix": "+234", "currencies": []string{"NGN"}, "paymentTypes": []string{"bank"}},
+	{"id": "GH", "name": "Ghana", "phonePrefix": "+233", "currencies": []string{"GHS"}, "paymentTypes": []string{"momo"}},
+}
+
+var deliveryBanks = map[string][]map[string]interface{}{
+	"UG": {
+		{"id": 1, "name": "Stanbic Bank Uganda", "code": "SBICUGKX"},
+		{"id": 2, "name": "Centenary Bank", "code": "CERBUGKA"},
+	},
+	"KE": {
+		{"id": 3, "name": "Equity Bank Kenya", "code": "EQBLKENA"},
+		{"id": 4, "name": "KCB Bank Kenya", "code": "KCBLKENX"},
+	},
+	"NG": {
+		{"id": 5, "name": "Access Bank", "code": "044"},
+	},
+}
+
+// route serves an authorized request. It is called with the mutex held.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string) {
+	get, post := r.Method == http.MethodGet, r.Method == http.MethodPost
+
+	switch {
+	case match(segments, "wallets") && get:
+		s.handleWallets(w)
+	case match(segments, "wallets", "*") && get:
+		s.handleWallet(w, segments[1])
+	case match(segments, "account") && get:
+		s.handleAccount(w)
+	case match(segments, "exchanges", "quotation") && post:
+		s.handleExchangeQuotation(w, r)
+	case match(segments, "exchanges") && post:
+		s.handleExchange(w, r)
+	case match(segments, "payouts", "countries") && get:
+		writeData(w, map[string]interface{}{"countries": deliveryCountries})
+	case match(segments, "payouts", "banks", "*") && get:
+		s.handleBanks(w, segments[2])
+	case match(segments, "payouts", "quotation") && post:
+		s.handlePayoutQuotation(w, r)
+	case match(segments, "payouts") && post:
+		s.handlePayout(w, r)
+	case match(segments, "transactions") && get:
+		s.handleTransactions(w, r)
+	case match(segments, "transactions", "*") && get:
+		s.handleTransaction(w, segments[1])
+	case match(segments, "beneficiaries") && get:
+		s.handleBeneficiaries(w)
+	case match(segments, "beneficiaries") && post:
+		s.handleCreateBeneficiary(w, r)
+	case match(segments, "beneficiaries", "*"):
+		s.handleBeneficiary(w, r, segments[1])
+	case match(segments, "collections", "fees") && post:
+		s.handleCollectionFees(w, r)
+	case match(segments, "collections", "otp") && post:
+		s.handleCollectionOTP(w, r)
+	case match(segments, "collections", "otp", "verify") && post:
+		s.handleVerifyOTP(w, r)
+	case match(segments, "collections", "momo") && post:
+		s.handleMomoCollection(w, r)
+	case match(segments, "crypto", "assets", "*") && get:
+		s.handleAssetChains(w, segments[2])
+	case match(segments, "crypto", "addresses") && get:
+		writeData(w, map[string]interface{}{"addresses": s.addresses})
+	case match(segments, "crypto", "addresses") && post:
+		s.handleCreateAddress(w, r)
+	case match(segments, "crypto", "addresses", "*", "transactions") && get:
+		s.handleAddressTransactions(w, segments[2])
+	case match(segments, "crypto", "transactions") && get:
+		writeData(w, map[string]interface{}{"transactions": s.cryptoTransactions})
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// match reports whether the path segments equal the pattern, where "*" matches any one segment.
+func match(segments []string, pattern ...string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+
+	return true
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (s *Server) wallet(currency string) map[string]interface{} {
+	return map[string]interface{}{
+		"currency": currency,
+		"amount":   round(s.wallets[currency]),
+		"enabled":  true,
+		"name":     currency + " Wallet",
+	}
+}
+
+func (s *Server) rate(from string, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+
+	rate, ok := s.rates[from+"/"+to]
+
+	return rate, ok
+}
+
+func (s *Server) addTransaction(transaction *Transaction) *Transaction {
+	transaction.TransactionID = "BE" + newID("")[:16]
+	transaction.CreatedAt = time.Now().UTC()
+	s.transactions[transaction.TransactionID] = transaction
+
+	return transaction
+}
+
+func (s *Server) handleWallets(w http.ResponseWriter) {
+	currencies := make([]string, 0, len(s.wallets))
+
+	for currency := range s.wallets {
+		currencies = append(currencies, currency)
+	}
+
+	sort.Strings(currencies)
+
+	wallets := []map[string]interface{}{}
+
+	for _, currency := range currencies {
+		wallets = append(wallets, s.wallet(currency))
+	}
+
+	writeData(w, wallets)
+}
+
+func (s *Server) handleWallet(w http.ResponseWriter, currency string) {
+	if _, ok := s.wallets[currency]; !ok {
+		writeError(w, http.StatusNotFound, "Wallet not found")
+		return
+	}
+
+	writeData(w, s.wallet(currency))
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter) {
+	writeData(w, map[string]interface{}{
+		"id":              "acc_test",
+		"businessName":    "Test Business",
+		"email":           "test@example.com",
+		"country":         "UG",
+		"defaultCurrency": "UGX",
+		"isVerified":      true,
+	})
+}
+
+func (s *Server) handleExchangeQuotation(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		From   string  `json:"from"`
+		Amount float64 `json:"amount"`
+		To     string  `json:"to"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	_, fromOk := s.wallets[body.From]
+	_, toOk := s.wallets[body.To]
+	rate, rateOk := s.rate(body.From, body.To)
+
+	if !fromOk || !toOk || !rateOk {
+		writeError(w, http.StatusBadRequest, "Currency pair not supported")
+		return
+	}
+
+	if body.Amount <= 0 {
+		writeError(w, http.StatusBadRequest, "Amount must be greater than 0")
+		return
+	}
+
+	token := newID("exq_")
+	q := &quotation{
+		kind:              "exchange",
+		from:              body.From,
+		to:                body.To,
+		amount:            body.Amount,
+		destinationAmount: round(body.Amount * rate),
+		rate:              rate,
+	}
+	s.quotations[token] = q
+
+	writeData(w, map[string]interface{}{
+		"token": token,
+		"quotation": map[string]interface{}{
+			"baseCurrency": q.from,
+			"baseAmount":   q.amount,
+			"destCurrency": q.to,
+			"destAmount":   q.destinationAmount,
+			"exchangeRate": q.rate,
+		},
+	})
+}
+
+func (s *Server) handleExchange(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Token string `json:"token"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	q := s.quotations[body.Token]
+
+	if q == nil || q.kind != "exchange" || q.used {
+		writeError(w, http.StatusBadRequest, "Invalid or expired token")
+		return
+	}
+
+	if s.wallets[q.from] < q.amount {
+		writeError(w, http.StatusBadRequest, "Insufficient balance")
+		return
+	}
+
+	q.used = true
+	s.wallets[q.from] -= q.amount
+	s.wallets[q.to] += q.destinationAmount
+
+	writeData(w, s.addTransaction(&Transaction{
+		Type:                "exchange",
+		Status:              "completed",
+		Currency:            q.from,
+		Amount:              q.amount,
+		DestinationCurrency: q.to,
+		DestinationAmount:   q.destinationAmount,
+	}))
+}
+
+func (s *Server) handleBanks(w http.ResponseWriter, countryCode string) {
+	banks := deliveryBanks[countryCode]
+
+	if banks == nil {
+		banks = []map[string]interface{}{}
+	}
+
+	writeData(w, banks)
+}
+
+func (s *Server) handlePayoutQuotation(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		SourceWallet        string  `json:"sourceWallet"`
+		Amount              float64 `json:"amount"`
+		Type                string  `json:"type"`
+		DestinationCountry  string  `json:"destinationCountry"`
+		DestinationCurrency string  `json:"destinationCurrency"`
+		AmountType          string  `json:"amountType"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if body.Type != "momo" && body.Type != "bank" {
+		writeError(w, http.StatusBadRequest, "type must be momo or bank")
+		return
+	}
+
+	if body.Amount <= 0 {
+		writeError(w, http.StatusBadRequest, "Amount must be greater than 0")
+		return
+	}
+
+	_, walletOk := s.wallets[body.SourceWallet]
+	rate, rateOk := s.rate(body.SourceWallet, body.DestinationCurrency)
+
+	if !walletOk || !rateOk {
+		writeError(w, http.StatusBadRequest, "Currency pair not supported")
+		return
+	}
+
+	q := &quotation{
+		kind:               "payout",
+		from:               body.SourceWallet,
+		to:                 body.DestinationCurrency,
+		rate:               rate,
+		payoutType:         body.Type,
+		destinationCountry: body.DestinationCountry,
+	}
+
+	if body.AmountType == "DESTINATION" {
+		q.destinationAmount = body.Amount
+		q.amount = round(body.Amount / rate)
+	} else {
+		q.amount = body.Amount
+		q.destinationAmount = round(body.Amount * rate)
+	}
+
+	q.fees = round(q.amount * s.payoutFeeRate)
+
+	token := newID("pq_")
+	s.quotations[token] = q
+
+	writeData(w, map[string]interface{}{
+		"token": token,
+		"quotation": map[string]interface{}{
+			"sourceCurrency":      q.from,
+			"sourceAmount":        q.amount,
+			"destinationCountry":  q.destinationCountry,
+			"destinationCurrency": q.to,
+			"destinationAmount":   q.destinationAmount,
+			"exchangeRate":        q.rate,
+			"totalFees":           q.fees,
+			"totalAmount":         round(q.amount + q.fees),
+			"type":                q.payoutType,
+			"amountType":          body.AmountType,
+		},
+	})
+}
+
+func (s *Server) handlePayout(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Token             string `json:"token"`
+		PhoneNumber       string `json:"phoneNumber"`
+		FirstName         string `json:"firstName"`
+		LastName          string `json:"lastName"`
+		Country           string `json:"country"`
+		BankName          string `json:"bankName"`
+		BankAccountName   string `json:"bankAccountName"`
+		BankCode          string `json:"bankCode"`
+		BankAccountNumber string `json:"bankAccountNumber"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	q := s.quotations[body.Token]
+
+	if q == nil || q.kind != "payout" || q.used {
+		writeError(w, http.StatusBadRequest, "Invalid or expired token")
+		return
+	}
+
+	if q.payoutType == "bank" && (body.BankCode == "" || body.BankAccountNumber == "") {
+		writeError(w, http.StatusBadRequest, "bankCode and bankAccountNumber are required")
+		return
+	}
+
+	total := q.amount + q.fees
+
+	if s.wallets[q.from] < total {
+		writeError(w, http.StatusBadRequest, "Insufficient balance")
+		return
+	}
+
+	q.used = true
+	s.wallets[q.from] -= total
+
+	writeData(w, s.addTransaction(&Transaction{
+		Type:                "payout",
+		Status:              "completed",
+		Currency:            q.from,
+		Amount:              q.amount,
+		Fees:                q.fees,
+		DestinationCurrency: q.to,
+		DestinationAmount:   q.destinationAmount,
+		DestinationCountry:  q.destinationCountry,
+		Country:             body.Country,
+		PhoneNumber:         body.PhoneNumber,
+		FirstName:           body.FirstName,
+		LastName:            body.LastName,
+		BankName:            body.BankName,
+		BankCode:            body.BankCode,
+		BankAccountName:     body.BankAccountName,
+		BankAccountNumber:   body.BankAccountNumber,
+	}))
+}
+
+func (s *Server) handleTransaction(w http.ResponseWriter, transactionID string) {
+	transaction := s.transactions[transactionID]
+
+	if transaction == nil {
+		writeError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+
+	writeData(w, transaction)
+}
+
+// handleTransactions lists the transactions oldest first, filtered by the from and to dates, inclusive, the type,
+// currency and status, a page of limit transactions at a time.
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var from, to time.Time
+
+	for _, bound := range []struct {
+		name  string
+		value *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if query.Get(bound.name) == "" {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", query.Get(bound.name))
+
+		if err != nil {
+			writeError(w, http.StatusBadRequest, bound.name+" must be a date e.g 2024-01-31")
+			return
+		}
+
+		*bound.value = date
+	}
+
+	page, limit := 1, 10
+
+	if value, err := strconv.Atoi(query.Get("page")); err == nil && value > 0 {
+		page = value
+	}
+
+	if value, err := strconv.Atoi(query.Get("limit")); err == nil && value > 0 {
+		limit = value
+	}
+
+	matches := []*Transaction{}
+
+	for _, transaction := range s.transactions {
+		day := transaction.CreatedAt.Truncate(24 * time.Hour)
+
+		if (!from.IsZero() && day.Before(from)) || (!to.IsZero() && day.After(to)) ||
+			!matchFilter(query.Get("type"), transaction.Type) ||
+			!matchFilter(query.Get("currency"), transaction.Currency) ||
+			!matchFilter(query.Get("status"), transaction.Status) {
+			continue
+		}
+
+		matches = append(matches, transaction)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+
+	start := (page - 1) * limit
+	end := start + limit
+
+	if start > len(matches) {
+		start = len(matches)
+	}
+
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	writeData(w, map[string]interface{}{
+		"transactions": matches[start:end],
+		"total":        len(matches),
+		"page":         page,
+		"limit":        limit,
+	})
+}
+
+func matchFilter(filter string, value string) bool {
+	return filter == "" || strings.EqualFold(filter, value)
+}
+
+func (s *Server) handleBeneficiaries(w http.ResponseWriter) {
+	ids := make([]int, 0, len(s.beneficiaries))
+
+	for id := range s.beneficiaries {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	beneficiaries := []*beneficiary{}
+
+	for _, id := range ids {
+		beneficiaries = append(beneficiaries, s.beneficiaries[id])
+	}
+
+	writeData(w, map[string]interface{}{"beneficiaries": beneficiaries})
+}
+
+func (s *Server) handleCreateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	b := &beneficiary{}
+
+	if !decodeBody(w, r, b) {
+		return
+	}
+
+	if b.FirstName == "" || b.Country == "" {
+		writeError(w, http.StatusBadRequest, "firstName and country are required")
+		return
+	}
+
+	b.ID = s.nextBeneficiaryID
+	s.nextBeneficiaryID++
+	s.beneficiaries[b.ID] = b
+
+	writeData(w, b)
+}
+
+func (s *Server) handleBeneficiary(w http.ResponseWriter, r *http.Request, id string) {
+	beneficiaryID, err := strconv.Atoi(id)
+	b := s.beneficiaries[beneficiaryID]
+
+	if err != nil || b == nil {
+		writeError(w, http.StatusNotFound, "Beneficiary not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, b)
+	case http.MethodDelete:
+		delete(s.beneficiaries, beneficiaryID)
+		writeData(w, nil)
+	case http.MethodPatch, http.MethodPut:
+		update := beneficiary{}
+
+		if !decodeBody(w, r, &update) {
+			return
+		}
+
+		for _, field := range []struct{ from, to *string }{
+			{&update.FirstName, &b.FirstName},
+			{&update.LastName, &b.LastName},
+			{&update.Email, &b.Email},
+			{&update.PhoneNumber, &b.PhoneNumber},
+			{&update.Country, &b.Country},
+			{&update.BankName, &b.BankName},
+			{&update.BankCode, &b.BankCode},
+			{&update.BankAccountName, &b.BankAccountName},
+			{&update.BankAccountNumber, &b.BankAccountNumber},
+		} {
+			if *field.from != "" {
+				*field.to = *field.from
+			}
+		}
+
+		writeData(w, b)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) handleCollectionFees(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Method   string  `json:"method"`
+		Currency string  `json:"currency"`
+		Amount   float64 `json:"amount"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if _, ok := s.wallets[body.Currency]; !ok {
+		writeError(w, http.StatusBadRequest, "Currency not supported")
+		return
+	}
+
+	charges := round(body.Amount * s.collectionFeeRate)
+
+	writeData(w, map[string]interface{}{
+		"method":      body.Method,
+		"currency":    body.Currency,
+		"amount":      body.Amount,
+		"charges":     charges,
+		"totalAmount": round(body.Amount + charges),
+	})
+}
+
+func (s *Server) handleCollectionOTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Phone string `json:"phone"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if body.Phone == "" {
+		writeError(w, http.StatusBadRequest, "phone is required")
+		return
+	}
+
+	pinID := newID("pin_")
+	s.otps[pinID] = true
+
+	writeData(w, map[string]interface{}{"pinId": pinID})
+}
+
+func (s *Server) handleVerifyOTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		PinID string `json:"pinId"`
+		Pin   string `json:"pin"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if !s.otps[body.PinID] || body.Pin != OTPPin {
+		writeError(w, http.StatusBadRequest, "Invalid OTP")
+		return
+	}
+
+	writeData(w, map[string]interface{}{"verified": true})
+}
+
+func (s *Server) handleMomoCollection(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Phone          string  `json:"phone"`
+		Amount         float64 `json:"amount"`
+		Country        string  `json:"country"`
+		Currency       string  `json:"currency"`
+		TransactionRef string  `json:"transactionRef"`
+		OTP            *struct {
+			PinID string `json:"pinId"`
+			Pin   string `json:"pin"`
+		} `json:"otp"`
+	}
+
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if body.OTP != nil && (!s.otps[body.OTP.PinID] || body.OTP.Pin != OTPPin) {
+		writeError(w, http.StatusBadRequest, "Invalid OTP")
+		return
+	}
+
+	if body.Amount <= 0 || body.Phone == "" {
+		writeError(w, http.StatusBadRequest, "phone and a positive amount are required")
+		return
+	}
+
+	if _, ok := s.wallets[body.Currency]; !ok {
+		writeError(w, http.StatusBadRequest, "Currency not supported")
+		return
+	}
+
+	s.wallets[body.Currency] += body.Amount
+
+	writeData(w, s.addTransaction(&Transaction{
+		TransactionRef: body.TransactionRef,
+		Type:           "collection",
+		Status:         "successful",
+		Currency:       body.Currency,
+		Amount:         body.Amount,
+		Fees:           round(body.Amount * s.collectionFeeRate),
+		Country:        body.Country,
+		Phone:          body.Phone,
+	}))
+}
@@ -0,0 +1,334 @@
+// Package eversendtest provides an in-process fake of the Eversend API for tests.
+//
+// The fake keeps an in-memory ledger of wallet balances that exchanges, payouts and collections move money between,
+// and can be told to fail requests to exercise error handling.
+//
+//	srv := eversendtest.NewServer(eversendtest.WithBalance("UGX", 500000))
+//	defer srv.Close()
+//
+//	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()))
+package eversendtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultClientID and DefaultClientSecret are the credentials the fake accepts unless WithCredentials is used.
+	DefaultClientID     = "test-client-id"
+	DefaultClientSecret = "test-client-secret"
+
+	// OTPPin is the pin the fake accepts for every OTP it sends.
+	OTPPin = "123456"
+)
+
+// Request is a request received by the fake. Path is relative to the base URL e.g "wallets/UGX".
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// Transaction is a transaction recorded by the fake ledger.
+type Transaction struct {
+	TransactionID       string    `json:"transactionId"`
+	TransactionRef      string    `json:"transactionRef,omitempty"`
+	Type                string    `json:"type"`
+	Status              string    `json:"status"`
+	Currency            string    `json:"currency"`
+	Amount              float64   `json:"amount"`
+	Fees                float64   `json:"fees"`
+	DestinationCurrency string    `json:"destinationCurrency,omitempty"`
+	DestinationAmount   float64   `json:"destinationAmount,omitempty"`
+	DestinationCountry  string    `json:"destinationCountry,omitempty"`
+	Country             string    `json:"country,omitempty"`
+	Phone               string    `json:"phone,omitempty"`
+	PhoneNumber         string    `json:"phoneNumber,omitempty"`
+	FirstName           string    `json:"firstName,omitempty"`
+	LastName            string    `json:"lastName,omitempty"`
+	BankName            string    `json:"bankName,omitempty"`
+	BankCode            string    `json:"bankCode,omitempty"`
+	BankAccountName     string    `json:"bankAccountName,omitempty"`
+	BankAccountNumber   string    `json:"bankAccountNumber,omitempty"`
+	CreatedAt           time.Time `json:"createdAt"`
+}
+
+// Server is a fake Eversend API served by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	mutex              sync.Mutex
+	tokenTTL           time.Duration
+	tokens             map[string]time.Time
+	wallets            map[string]float64
+	rates              map[string]float64
+	payoutFeeRate      float64
+	collectionFeeRate  float64
+	quotations         map[string]*quotation
+	transactions       map[string]*Transaction
+	beneficiaries      map[int]*beneficiary
+	nextBeneficiaryID  int
+	addresses          []*cryptoAddress
+	cryptoTransactions []*cryptoTransaction
+	otps               map[string]bool
+	faults             []*Fault
+	requests           []Request
+}
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithCredentials sets the client id and secret the fake accepts.
+func WithCredentials(clientID string, clientSecret string) Option {
+	return func(s *Server) {
+		s.ClientID = clientID
+		s.ClientSecret = clientSecret
+	}
+}
+
+// WithBalance sets the starting balance of a wallet. Wallets that are not set start with the default balances.
+func WithBalance(currency string, amount float64) Option {
+	return func(s *Server) {
+		s.wallets[currency] = amount
+	}
+}
+
+// WithRate sets the exchange rate used to convert from one currency to another.
+func WithRate(from string, to string, rate float64) Option {
+	return func(s *Server) {
+		s.rates[from+"/"+to] = rate
+	}
+}
+
+// WithTokenTTL sets how long the auth tokens issued by the fake are valid. The default is one hour.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.tokenTTL = ttl
+	}
+}
+
+// WithPayoutFeeRate sets the fee charged on payouts as a fraction of the source amount. The default is 0.01.
+func WithPayoutFeeRate(rate float64) Option {
+	return func(s *Server) {
+		s.payoutFeeRate = rate
+	}
+}
+
+// NewServer function to start a fake Eversend API. It must be closed with Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		ClientID:     DefaultClientID,
+		ClientSecret: DefaultClientSecret,
+		tokenTTL:     time.Hour,
+		tokens:       map[string]time.Time{},
+		wallets: map[string]float64{
+			"UGX": 1000000,
+			"KES": 100000,
+			"USD": 1000,
+		},
+		rates: map[string]float64{
+			"UGX/KES": 0.035,
+			"KES/UGX": 28.5,
+			"USD/UGX": 3700,
+			"UGX/USD": 0.00027,
+			"USD/KES": 130,
+			"KES/USD": 0.0077,
+		},
+		payoutFeeRate:     0.01,
+		collectionFeeRate: 0.015,
+		quotations:        map[string]*quotation{},
+		transactions:      map[string]*Transaction{},
+		beneficiaries:     map[int]*beneficiary{},
+		nextBeneficiaryID: 1,
+		otps:              map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// BaseURL returns the URL to pass to eversendSdk.WithBaseUrl.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v1/"
+}
+
+// SetBalance changes the balance of a wallet.
+func (s *Server) SetBalance(currency string, amount float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.wallets[currency] = amount
+}
+
+// Balance returns the balance of a wallet.
+func (s *Server) Balance(currency string) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.wallets[currency]
+}
+
+// SetRate changes the exchange rate used to convert from one currency to another.
+func (s *Server) SetRate(from string, to string, rate float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.rates[from+"/"+to] = rate
+}
+
+// ExpireTokens invalidates every auth token issued so far, so requests using them are rejected with a 401.
+func (s *Server) ExpireTokens() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+// Transactions returns the transactions recorded by the ledger, oldest first.
+func (s *Server) Transactions() []Transaction {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	transactions := make([]Transaction, 0, len(s.transactions))
+
+	for _, transaction := range s.transactions {
+		transactions = append(transactions, *transaction)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+	})
+
+	return transactions
+}
+
+// SetTransactionStatus changes the status of a transaction of the ledger e.g to "failed" or "reversed".
+func (s *Server) SetTransactionStatus(transactionID string, status string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if transaction := s.transactions[transactionID]; transaction != nil {
+		transaction.Status = status
+	}
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns how many requests were received for the method and path e.g ("GET", "auth/token").
+func (s *Server) RequestCount(method string, path string) int {
+	count := 0
+
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Header: r.Header.Clone()})
+	fault := s.matchFault(r.Method, path)
+	s.mutex.Unlock()
+
+	if fault != nil {
+		s.serveFault(w, r, fault)
+		return
+	}
+
+	if path == "auth/token" && r.Method == http.MethodGet {
+		s.handleAuthToken(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.route(w, r, strings.Split(strings.Trim(path, "/"), "/"))
+}
+
+func (s *Server) handleAuthToken(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("clientId") != s.ClientID || r.Header.Get("clientSecret") != s.ClientSecret {
+		writeError(w, http.StatusUnauthorized, "Invalid client credentials")
+		return
+	}
+
+	token := newID("tok_")
+	expires := time.Now().Add(s.tokenTTL).UTC()
+
+	s.mutex.Lock()
+	s.tokens[token] = expires
+	s.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":   token,
+		"expires": expires.Format(time.RFC3339),
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expires, ok := s.tokens[token]
+
+	return ok && time.Now().Before(expires)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"code":    http.StatusOK,
+		"success": true,
+		"data":    data,
+	})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"code":    statusCode,
+		"success": false,
+		"message": message,
+	})
+}
+
+func newID(prefix string) string {
+	return prefix + randomHex(12)
+}
@@ -0,0 +1,138 @@
+package eversendtest_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func newTestApp(t *testing.T, opts ...eversendtest.Option) (*eversendSdk.Eversend, *eversendtest.Server) {
+	t.Helper()
+
+	srv := eversendtest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	return eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL())), srv
+}
+
+func TestPayoutMovesLedger(t *testing.T) {
+	app, srv := newTestApp(t, eversendtest.WithBalance("UGX", 50000))
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payout, err := app.Payouts.MomoPayout(quotation["token"].(string), "+254712345678", "Jane", "Doe", "KE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the amount and the 1% fee
+	if got := srv.Balance("UGX"); got != 39900 {
+		t.Fatalf("got balance %v, want 39900", got)
+	}
+
+	transactions := srv.Transactions()
+
+	if len(transactions) != 1 || transactions[0].TransactionID != payout["transactionId"] || transactions[0].Fees != 100 {
+		t.Fatalf("got transactions %+v for payout %v", transactions, payout)
+	}
+
+	// a quotation token is used once
+	if _, err := app.Payouts.MomoPayout(quotation["token"].(string), "+254712345678", "Jane", "Doe", "KE"); err == nil {
+		t.Fatal("quotation token was used twice")
+	}
+
+	srv.SetBalance("UGX", 100)
+	quotation, _ = app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "")
+
+	if _, err := app.Payouts.MomoPayout(quotation["token"].(string), "+254712345678", "Jane", "Doe", "KE"); err == nil || err.Error() != "Insufficient balance" {
+		t.Fatalf("got error %v, want Insufficient balance", err)
+	}
+
+	if got := srv.Balance("UGX"); got != 100 {
+		t.Fatalf("got balance %v after a failed payout, want 100", got)
+	}
+}
+
+func TestCredentialsAreChecked(t *testing.T) {
+	srv := eversendtest.NewServer(eversendtest.WithCredentials("id", "secret"))
+	t.Cleanup(srv.Close)
+
+	app := eversendSdk.NewEversendApp("id", "wrong", eversendSdk.WithBaseUrl(srv.BaseURL()))
+
+	if _, err := app.Wallets.List(); err == nil || err.Error() != "Invalid client credentials" {
+		t.Fatalf("got error %v, want Invalid client credentials", err)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "wallets"); got != 0 {
+		t.Fatalf("got %d wallet requests without a token", got)
+	}
+}
+
+func TestInjectFault(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultServerError, Method: http.MethodGet, Path: "wallets", Times: 1})
+
+	if _, err := app.Wallets.Find("UGX"); err == nil || err.Error() != "Internal Server Error" {
+		t.Fatalf("got error %v, want Internal Server Error", err)
+	}
+
+	// the fault is used up
+	if _, err := app.Wallets.Find("UGX"); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultMalformedResponse, Path: "account"})
+
+	for i := 0; i < 2; i++ {
+		if _, err := app.AccountProfile(); err == nil {
+			t.Fatal("decoded a malformed response")
+		}
+	}
+
+	srv.ClearFaults()
+
+	if _, err := app.AccountProfile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "wallets/UGX"); got != 2 {
+		t.Fatalf("got %d wallet requests, want 2", got)
+	}
+}
+
+func TestParallelServers(t *testing.T) {
+	for i := 1; i <= 4; i++ {
+		balance := float64(i * 1000)
+
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			app, srv := newTestApp(t, eversendtest.WithBalance("USD", balance))
+
+			for j := 0; j < 10; j++ {
+				wallet, err := app.Wallets.Find("USD")
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if wallet["amount"] != balance {
+					t.Fatalf("got balance %v of another server, want %v", wallet["amount"], balance)
+				}
+			}
+
+			if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 1 {
+				t.Fatalf("got %d token requests, want 1", got)
+			}
+		})
+	}
+}
@@ -2,6 +2,10 @@ module github.com/cetric32/eversend_go_sdk
 
 go 1.21.6
 
-require github.com/joho/godotenv v1.5.1
+require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/joho/godotenv v1.5.1
+	golang.org/x/crypto v0.20.0
+)
 
-require github.com/cetric32/GoHTTP v0.2.4
+require golang.org/x/sys v0.17.0 // indirect
@@ -0,0 +1,156 @@
+package eversendSdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RoundTripFunc sends a request to the Eversend API and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every request made by the SDK, including the auth token request.
+// It can change the request before calling next, e.g to add a tracing header, and inspect or replace the response it returns.
+// Operation(req.Context()) gives the name of the SDK operation the request belongs to.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+type operationKey struct{}
+type attemptKey struct{}
+
+// Operation returns the name of the SDK operation a request is made for e.g "payouts.create" or "auth.token".
+// It returns an empty string for a context that is not from a request made by the SDK.
+func Operation(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+// Attempt returns which attempt at sending a request this is, starting at 1.
+// It returns 0 for a context that is not from a request made by the SDK.
+func Attempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
+
+// send makes a request to the path relative to the base url through the middlewares and returns the response body and status code.
+// The reqBody, if not nil, is sent as JSON. The request is made with ctx, which the middlewares get as req.Context()
+// along with the operation and attempt.
+func (c *client) send(ctx context.Context, operation string, attempt int, method string, path string, headers map[string]string, reqBody interface{}) (responseData []byte, statusCode int, err error) {
+	url := c.baseUrl + path
+	chain := c.middlewares
+
+	var body io.Reader
+
+	if reqBody != nil {
+		content, err := json.Marshal(reqBody)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		body = bytes.NewReader(content)
+	}
+
+	ctx = context.WithValue(ctx, operationKey{}, operation)
+	ctx = context.WithValue(ctx, attemptKey{}, attempt)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	roundTrip := RoundTripFunc(c.httpClient.Do)
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		roundTrip = chain[i](roundTrip)
+	}
+
+	resp, err := roundTrip(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	responseData, err = io.ReadAll(resp.Body)
+
+	return responseData, resp.StatusCode, err
+}
+
+// call makes an authenticated request and decodes the response into responseData.
+// A response whose status code is not 200 is returned as an error. When the API rejects the auth token with a 401,
+// e.g because it was revoked before it expired, a new token is requested and the request is sent a second time.
+func (c *client) call(ctx context.Context, operation string, method string, path string, reqBody interface{}, responseData interface{}) error {
+	for attempt := 1; ; attempt++ {
+		token, err := c.generateAuthToken(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		body, statusCode, err := c.send(ctx, operation, attempt, method, path, map[string]string{
+			"Authorization": "Bearer " + token,
+		}, reqBody)
+
+		if err != nil {
+			return err
+		}
+
+		if statusCode == http.StatusUnauthorized && attempt == 1 {
+			c.invalidateAuthToken(token)
+			continue
+		}
+
+		if statusCode != 200 {
+			return statusError(statusCode, body)
+		}
+
+		if responseData == nil {
+			// the body is still decoded so that a malformed response is reported
+			responseData = &struct{}{}
+		}
+
+		return json.Unmarshal(body, responseData)
+	}
+}
+
+// APIError is the error returned for a response of the Eversend API whose status code is not 200.
+type APIError struct {
+	StatusCode int
+	// Message is the "message" field of the response, which some error responses do not have.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("eversend: request failed with status code %d", e.StatusCode)
+}
+
+// statusError returns the error for a response body whose status code is not 200.
+func statusError(statusCode int, body []byte) error {
+	var errorData struct {
+		Message interface{} `json:"message"`
+	}
+
+	// the body of some error responses, e.g from a proxy, is not JSON
+	_ = json.Unmarshal(body, &errorData)
+
+	message, _ := errorData.Message.(string)
+
+	return &APIError{StatusCode: statusCode, Message: message}
+}
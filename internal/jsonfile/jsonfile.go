@@ -0,0 +1,62 @@
+// Package jsonfile reads and writes the JSON files the stores of the SDK keep their state in.
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Read decodes the file at path into value. A file that does not exist, or is empty, leaves value unchanged.
+func Read(path string, value interface{}) error {
+	content, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(content) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(content, value)
+}
+
+// Write replaces the file at path with value encoded as JSON. The file is replaced atomically, with a synced
+// temporary file renamed over it, so that a crash never leaves it half written.
+func Write(path string, value interface{}) error {
+	content, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
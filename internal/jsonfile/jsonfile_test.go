@@ -0,0 +1,38 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	value := map[string]int{"unchanged": 1}
+
+	if err := Read(path, &value); err != nil || value["unchanged"] != 1 {
+		t.Fatalf("got %v and %v reading a file that does not exist", value, err)
+	}
+
+	if err := Write(path, map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Write(path, map[string]int{"b": 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	read := map[string]int{}
+
+	if err := Read(path, &read); err != nil || len(read) != 1 || read["b"] != 2 {
+		t.Fatalf("got %v and %v, want the last value written", read, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("got %d files and %v, want no temporary file left", len(entries), err)
+	}
+}
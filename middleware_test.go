@@ -0,0 +1,161 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	calls := []string{}
+
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()),
+		WithMiddleware(record("outer")), WithMiddleware(record("inner")))
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the token request and the wallets request
+	want := strings.Repeat("outer before,inner before,inner after,outer after,", 2)
+
+	if got := strings.Join(calls, ",") + ","; got != want {
+		t.Fatalf("got calls %s, want %s", got, want)
+	}
+}
+
+func TestMiddlewareSeesEveryEndpoint(t *testing.T) {
+	var lock sync.Mutex
+	operations := map[string]bool{}
+
+	tracing := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			lock.Lock()
+			operations[Operation(req.Context())] = true
+			lock.Unlock()
+
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next(req)
+		}
+	}
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(tracing))
+
+	for _, tc := range endpointCalls {
+		tc.call(app)
+	}
+
+	for _, request := range srv.Requests() {
+		if request.Header.Get("X-Trace-Id") != "trace-1" {
+			t.Errorf("%s %s was sent without the middleware header", request.Method, request.Path)
+		}
+	}
+
+	for _, operation := range []string{"auth.token", "wallets.list", "payouts.create", "beneficiaries.delete", "crypto.addresses.create", "collections.status"} {
+		if !operations[operation] {
+			t.Errorf("middleware did not see operation %q", operation)
+		}
+	}
+
+	if operations[""] {
+		t.Error("middleware saw a request without an operation")
+	}
+}
+
+func TestMiddlewareCanShortCircuit(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	errChaos := errors.New("chaos")
+
+	chaos := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if Operation(req.Context()) == "payouts.countries" {
+				return nil, errChaos
+			}
+
+			return next(req)
+		}
+	}
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(chaos))
+
+	if _, err := app.Payouts.DeliveryCountries(); !errors.Is(err, errChaos) {
+		t.Fatalf("got error %v, want the middleware error", err)
+	}
+
+	if srv.RequestCount(http.MethodGet, "payouts/countries") != 0 {
+		t.Fatal("the request reached the server")
+	}
+}
+
+func TestRequestsUseCallerContext(t *testing.T) {
+	type traceKey struct{}
+
+	var lock sync.Mutex
+	traces := []interface{}{}
+
+	tracing := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			lock.Lock()
+			traces = append(traces, req.Context().Value(traceKey{}))
+			lock.Unlock()
+
+			return next(req)
+		}
+	}
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(tracing))
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "trace-1")
+
+	if _, err := app.Wallets.ListContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the token request is part of the call
+	if len(traces) != 2 || traces[0] != "trace-1" || traces[1] != "trace-1" {
+		t.Fatalf("got traces %v, want the caller context on both requests", traces)
+	}
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultTimeout, Path: "payouts"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := app.Payouts.DeliveryCountriesContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("call returned after %s, the deadline was not used", elapsed)
+	}
+}
@@ -0,0 +1,3 @@
+// Package mocks provides mock implementations of the eversendSdk service interfaces, generated with moq
+// (github.com/matryer/moq). Regenerate them with "go generate" in the root package after changing an interface.
+package mocks
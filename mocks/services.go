@@ -0,0 +1,3512 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/cetric32/eversend_go_sdk"
+	"sync"
+)
+
+// Ensure, that WalletServiceMock does implement eversendSdk.WalletService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.WalletService = &WalletServiceMock{}
+
+// WalletServiceMock is a mock implementation of eversendSdk.WalletService.
+//
+//	func TestSomethingThatUsesWalletService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.WalletService
+//		mockedWalletService := &WalletServiceMock{
+//			FindFunc: func(walletCurrency string) (map[string]interface{}, error) {
+//				panic("mock out the Find method")
+//			},
+//			FindContextFunc: func(ctx context.Context, walletCurrency string) (map[string]interface{}, error) {
+//				panic("mock out the FindContext method")
+//			},
+//			ListFunc: func() ([]interface{}, error) {
+//				panic("mock out the List method")
+//			},
+//			ListContextFunc: func(ctx context.Context) ([]interface{}, error) {
+//				panic("mock out the ListContext method")
+//			},
+//		}
+//
+//		// use mockedWalletService in code that requires eversendSdk.WalletService
+//		// and then make assertions.
+//
+//	}
+type WalletServiceMock struct {
+	// FindFunc mocks the Find method.
+	FindFunc func(walletCurrency string) (map[string]interface{}, error)
+
+	// FindContextFunc mocks the FindContext method.
+	FindContextFunc func(ctx context.Context, walletCurrency string) (map[string]interface{}, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func() ([]interface{}, error)
+
+	// ListContextFunc mocks the ListContext method.
+	ListContextFunc func(ctx context.Context) ([]interface{}, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Find holds details about calls to the Find method.
+		Find []struct {
+			// WalletCurrency is the walletCurrency argument value.
+			WalletCurrency string
+		}
+		// FindContext holds details about calls to the FindContext method.
+		FindContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WalletCurrency is the walletCurrency argument value.
+			WalletCurrency string
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+		}
+		// ListContext holds details about calls to the ListContext method.
+		ListContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockFind        sync.RWMutex
+	lockFindContext sync.RWMutex
+	lockList        sync.RWMutex
+	lockListContext sync.RWMutex
+}
+
+// Find calls FindFunc.
+func (mock *WalletServiceMock) Find(walletCurrency string) (map[string]interface{}, error) {
+	if mock.FindFunc == nil {
+		panic("WalletServiceMock.FindFunc: method is nil but WalletService.Find was just called")
+	}
+	callInfo := struct {
+		WalletCurrency string
+	}{
+		WalletCurrency: walletCurrency,
+	}
+	mock.lockFind.Lock()
+	mock.calls.Find = append(mock.calls.Find, callInfo)
+	mock.lockFind.Unlock()
+	return mock.FindFunc(walletCurrency)
+}
+
+// FindCalls gets all the calls that were made to Find.
+// Check the length with:
+//
+//	len(mockedWalletService.FindCalls())
+func (mock *WalletServiceMock) FindCalls() []struct {
+	WalletCurrency string
+} {
+	var calls []struct {
+		WalletCurrency string
+	}
+	mock.lockFind.RLock()
+	calls = mock.calls.Find
+	mock.lockFind.RUnlock()
+	return calls
+}
+
+// FindContext calls FindContextFunc.
+func (mock *WalletServiceMock) FindContext(ctx context.Context, walletCurrency string) (map[string]interface{}, error) {
+	if mock.FindContextFunc == nil {
+		panic("WalletServiceMock.FindContextFunc: method is nil but WalletService.FindContext was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		WalletCurrency string
+	}{
+		Ctx:            ctx,
+		WalletCurrency: walletCurrency,
+	}
+	mock.lockFindContext.Lock()
+	mock.calls.FindContext = append(mock.calls.FindContext, callInfo)
+	mock.lockFindContext.Unlock()
+	return mock.FindContextFunc(ctx, walletCurrency)
+}
+
+// FindContextCalls gets all the calls that were made to FindContext.
+// Check the length with:
+//
+//	len(mockedWalletService.FindContextCalls())
+func (mock *WalletServiceMock) FindContextCalls() []struct {
+	Ctx            context.Context
+	WalletCurrency string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		WalletCurrency string
+	}
+	mock.lockFindContext.RLock()
+	calls = mock.calls.FindContext
+	mock.lockFindContext.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *WalletServiceMock) List() ([]interface{}, error) {
+	if mock.ListFunc == nil {
+		panic("WalletServiceMock.ListFunc: method is nil but WalletService.List was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc()
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedWalletService.ListCalls())
+func (mock *WalletServiceMock) ListCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ListContext calls ListContextFunc.
+func (mock *WalletServiceMock) ListContext(ctx context.Context) ([]interface{}, error) {
+	if mock.ListContextFunc == nil {
+		panic("WalletServiceMock.ListContextFunc: method is nil but WalletService.ListContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListContext.Lock()
+	mock.calls.ListContext = append(mock.calls.ListContext, callInfo)
+	mock.lockListContext.Unlock()
+	return mock.ListContextFunc(ctx)
+}
+
+// ListContextCalls gets all the calls that were made to ListContext.
+// Check the length with:
+//
+//	len(mockedWalletService.ListContextCalls())
+func (mock *WalletServiceMock) ListContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListContext.RLock()
+	calls = mock.calls.ListContext
+	mock.lockListContext.RUnlock()
+	return calls
+}
+
+// Ensure, that ExchangeServiceMock does implement eversendSdk.ExchangeService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.ExchangeService = &ExchangeServiceMock{}
+
+// ExchangeServiceMock is a mock implementation of eversendSdk.ExchangeService.
+//
+//	func TestSomethingThatUsesExchangeService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.ExchangeService
+//		mockedExchangeService := &ExchangeServiceMock{
+//			ExchangeFunc: func(exchangeToken string) (map[string]interface{}, error) {
+//				panic("mock out the Exchange method")
+//			},
+//			ExchangeContextFunc: func(ctx context.Context, exchangeToken string) (map[string]interface{}, error) {
+//				panic("mock out the ExchangeContext method")
+//			},
+//			QuotationFunc: func(from string, amount float64, to string) (map[string]interface{}, error) {
+//				panic("mock out the Quotation method")
+//			},
+//			QuotationContextFunc: func(ctx context.Context, from string, amount float64, to string) (map[string]interface{}, error) {
+//				panic("mock out the QuotationContext method")
+//			},
+//		}
+//
+//		// use mockedExchangeService in code that requires eversendSdk.ExchangeService
+//		// and then make assertions.
+//
+//	}
+type ExchangeServiceMock struct {
+	// ExchangeFunc mocks the Exchange method.
+	ExchangeFunc func(exchangeToken string) (map[string]interface{}, error)
+
+	// ExchangeContextFunc mocks the ExchangeContext method.
+	ExchangeContextFunc func(ctx context.Context, exchangeToken string) (map[string]interface{}, error)
+
+	// QuotationFunc mocks the Quotation method.
+	QuotationFunc func(from string, amount float64, to string) (map[string]interface{}, error)
+
+	// QuotationContextFunc mocks the QuotationContext method.
+	QuotationContextFunc func(ctx context.Context, from string, amount float64, to string) (map[string]interface{}, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Exchange holds details about calls to the Exchange method.
+		Exchange []struct {
+			// ExchangeToken is the exchangeToken argument value.
+			ExchangeToken string
+		}
+		// ExchangeContext holds details about calls to the ExchangeContext method.
+		ExchangeContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ExchangeToken is the exchangeToken argument value.
+			ExchangeToken string
+		}
+		// Quotation holds details about calls to the Quotation method.
+		Quotation []struct {
+			// From is the from argument value.
+			From string
+			// Amount is the amount argument value.
+			Amount float64
+			// To is the to argument value.
+			To string
+		}
+		// QuotationContext holds details about calls to the QuotationContext method.
+		QuotationContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From string
+			// Amount is the amount argument value.
+			Amount float64
+			// To is the to argument value.
+			To string
+		}
+	}
+	lockExchange         sync.RWMutex
+	lockExchangeContext  sync.RWMutex
+	lockQuotation        sync.RWMutex
+	lockQuotationContext sync.RWMutex
+}
+
+// Exchange calls ExchangeFunc.
+func (mock *ExchangeServiceMock) Exchange(exchangeToken string) (map[string]interface{}, error) {
+	if mock.ExchangeFunc == nil {
+		panic("ExchangeServiceMock.ExchangeFunc: method is nil but ExchangeService.Exchange was just called")
+	}
+	callInfo := struct {
+		ExchangeToken string
+	}{
+		ExchangeToken: exchangeToken,
+	}
+	mock.lockExchange.Lock()
+	mock.calls.Exchange = append(mock.calls.Exchange, callInfo)
+	mock.lockExchange.Unlock()
+	return mock.ExchangeFunc(exchangeToken)
+}
+
+// ExchangeCalls gets all the calls that were made to Exchange.
+// Check the length with:
+//
+//	len(mockedExchangeService.ExchangeCalls())
+func (mock *ExchangeServiceMock) ExchangeCalls() []struct {
+	ExchangeToken string
+} {
+	var calls []struct {
+		ExchangeToken string
+	}
+	mock.lockExchange.RLock()
+	calls = mock.calls.Exchange
+	mock.lockExchange.RUnlock()
+	return calls
+}
+
+// ExchangeContext calls ExchangeContextFunc.
+func (mock *ExchangeServiceMock) ExchangeContext(ctx context.Context, exchangeToken string) (map[string]interface{}, error) {
+	if mock.ExchangeContextFunc == nil {
+		panic("ExchangeServiceMock.ExchangeContextFunc: method is nil but ExchangeService.ExchangeContext was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ExchangeToken string
+	}{
+		Ctx:           ctx,
+		ExchangeToken: exchangeToken,
+	}
+	mock.lockExchangeContext.Lock()
+	mock.calls.ExchangeContext = append(mock.calls.ExchangeContext, callInfo)
+	mock.lockExchangeContext.Unlock()
+	return mock.ExchangeContextFunc(ctx, exchangeToken)
+}
+
+// ExchangeContextCalls gets all the calls that were made to ExchangeContext.
+// Check the length with:
+//
+//	len(mockedExchangeService.ExchangeContextCalls())
+func (mock *ExchangeServiceMock) ExchangeContextCalls() []struct {
+	Ctx           context.Context
+	ExchangeToken string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ExchangeToken string
+	}
+	mock.lockExchangeContext.RLock()
+	calls = mock.calls.ExchangeContext
+	mock.lockExchangeContext.RUnlock()
+	return calls
+}
+
+// Quotation calls QuotationFunc.
+func (mock *ExchangeServiceMock) Quotation(from string, amount float64, to string) (map[string]interface{}, error) {
+	if mock.QuotationFunc == nil {
+		panic("ExchangeServiceMock.QuotationFunc: method is nil but ExchangeService.Quotation was just called")
+	}
+	callInfo := struct {
+		From   string
+		Amount float64
+		To     string
+	}{
+		From:   from,
+		Amount: amount,
+		To:     to,
+	}
+	mock.lockQuotation.Lock()
+	mock.calls.Quotation = append(mock.calls.Quotation, callInfo)
+	mock.lockQuotation.Unlock()
+	return mock.QuotationFunc(from, amount, to)
+}
+
+// QuotationCalls gets all the calls that were made to Quotation.
+// Check the length with:
+//
+//	len(mockedExchangeService.QuotationCalls())
+func (mock *ExchangeServiceMock) QuotationCalls() []struct {
+	From   string
+	Amount float64
+	To     string
+} {
+	var calls []struct {
+		From   string
+		Amount float64
+		To     string
+	}
+	mock.lockQuotation.RLock()
+	calls = mock.calls.Quotation
+	mock.lockQuotation.RUnlock()
+	return calls
+}
+
+// QuotationContext calls QuotationContextFunc.
+func (mock *ExchangeServiceMock) QuotationContext(ctx context.Context, from string, amount float64, to string) (map[string]interface{}, error) {
+	if mock.QuotationContextFunc == nil {
+		panic("ExchangeServiceMock.QuotationContextFunc: method is nil but ExchangeService.QuotationContext was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		From   string
+		Amount float64
+		To     string
+	}{
+		Ctx:    ctx,
+		From:   from,
+		Amount: amount,
+		To:     to,
+	}
+	mock.lockQuotationContext.Lock()
+	mock.calls.QuotationContext = append(mock.calls.QuotationContext, callInfo)
+	mock.lockQuotationContext.Unlock()
+	return mock.QuotationContextFunc(ctx, from, amount, to)
+}
+
+// QuotationContextCalls gets all the calls that were made to QuotationContext.
+// Check the length with:
+//
+//	len(mockedExchangeService.QuotationContextCalls())
+func (mock *ExchangeServiceMock) QuotationContextCalls() []struct {
+	Ctx    context.Context
+	From   string
+	Amount float64
+	To     string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		From   string
+		Amount float64
+		To     string
+	}
+	mock.lockQuotationContext.RLock()
+	calls = mock.calls.QuotationContext
+	mock.lockQuotationContext.RUnlock()
+	return calls
+}
+
+// Ensure, that PayoutServiceMock does implement eversendSdk.PayoutService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.PayoutService = &PayoutServiceMock{}
+
+// PayoutServiceMock is a mock implementation of eversendSdk.PayoutService.
+//
+//	func TestSomethingThatUsesPayoutService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.PayoutService
+//		mockedPayoutService := &PayoutServiceMock{
+//			BankPayoutFunc: func(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error) {
+//				panic("mock out the BankPayout method")
+//			},
+//			BankPayoutContextFunc: func(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error) {
+//				panic("mock out the BankPayoutContext method")
+//			},
+//			DeliveryBanksFunc: func(countryCode string) ([]interface{}, error) {
+//				panic("mock out the DeliveryBanks method")
+//			},
+//			DeliveryBanksContextFunc: func(ctx context.Context, countryCode string) ([]interface{}, error) {
+//				panic("mock out the DeliveryBanksContext method")
+//			},
+//			DeliveryCountriesFunc: func() ([]interface{}, error) {
+//				panic("mock out the DeliveryCountries method")
+//			},
+//			DeliveryCountriesContextFunc: func(ctx context.Context) ([]interface{}, error) {
+//				panic("mock out the DeliveryCountriesContext method")
+//			},
+//			MomoPayoutFunc: func(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
+//				panic("mock out the MomoPayout method")
+//			},
+//			MomoPayoutContextFunc: func(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
+//				panic("mock out the MomoPayoutContext method")
+//			},
+//			QuotationFunc: func(sourceWallet string, amount float64, transactionType string, destinationCountry string, destinationCurrency string, amountType string) (map[string]interface{}, error) {
+//				panic("mock out the Quotation method")
+//			},
+//			QuotationContextFunc: func(ctx context.Context, sourceWallet string, amount float64, transactionType string, destinationCountry string, destinationCurrency string, amountType string) (map[string]interface{}, error) {
+//				panic("mock out the QuotationContext method")
+//			},
+//			TransactionFunc: func(transactionId string) (map[string]interface{}, error) {
+//				panic("mock out the Transaction method")
+//			},
+//			TransactionContextFunc: func(ctx context.Context, transactionId string) (map[string]interface{}, error) {
+//				panic("mock out the TransactionContext method")
+//			},
+//		}
+//
+//		// use mockedPayoutService in code that requires eversendSdk.PayoutService
+//		// and then make assertions.
+//
+//	}
+type PayoutServiceMock struct {
+	// BankPayoutFunc mocks the BankPayout method.
+	BankPayoutFunc func(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error)
+
+	// BankPayoutContextFunc mocks the BankPayoutContext method.
+	BankPayoutContextFunc func(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error)
+
+	// DeliveryBanksFunc mocks the DeliveryBanks method.
+	DeliveryBanksFunc func(countryCode string) ([]interface{}, error)
+
+	// DeliveryBanksContextFunc mocks the DeliveryBanksContext method.
+	DeliveryBanksContextFunc func(ctx context.Context, countryCode string) ([]interface{}, error)
+
+	// DeliveryCountriesFunc mocks the DeliveryCountries method.
+	DeliveryCountriesFunc func() ([]interface{}, error)
+
+	// DeliveryCountriesContextFunc mocks the DeliveryCountriesContext method.
+	DeliveryCountriesContextFunc func(ctx context.Context) ([]interface{}, error)
+
+	// MomoPayoutFunc mocks the MomoPayout method.
+	MomoPayoutFunc func(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error)
+
+	// MomoPayoutContextFunc mocks the MomoPayoutContext method.
+	MomoPayoutContextFunc func(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error)
+
+	// QuotationFunc mocks the Quotation method.
+	QuotationFunc func(sourceWallet string, amount float64, transactionType string, destinationCountry string, destinationCurrency string, amountType string) (map[string]interface{}, error)
+
+	// QuotationContextFunc mocks the QuotationContext method.
+	QuotationContextFunc func(ctx context.Context, sourceWallet string, amount float64, transactionType string, destinationCountry string, destinationCurrency string, amountType string) (map[string]interface{}, error)
+
+	// TransactionFunc mocks the Transaction method.
+	TransactionFunc func(transactionId string) (map[string]interface{}, error)
+
+	// TransactionContextFunc mocks the TransactionContext method.
+	TransactionContextFunc func(ctx context.Context, transactionId string) (map[string]interface{}, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// BankPayout holds details about calls to the BankPayout method.
+		BankPayout []struct {
+			// PayoutToken is the payoutToken argument value.
+			PayoutToken string
+			// PhoneNumber is the phoneNumber argument value.
+			PhoneNumber string
+			// FirstName is the firstName argument value.
+			FirstName string
+			// LastName is the lastName argument value.
+			LastName string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+			// BankName is the bankName argument value.
+			BankName string
+			// BankAccountName is the bankAccountName argument value.
+			BankAccountName string
+			// BankCode is the bankCode argument value.
+			BankCode string
+			// BankAccountNumber is the bankAccountNumber argument value.
+			BankAccountNumber string
+		}
+		// BankPayoutContext holds details about calls to the BankPayoutContext method.
+		BankPayoutContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PayoutToken is the payoutToken argument value.
+			PayoutToken string
+			// PhoneNumber is the phoneNumber argument value.
+			PhoneNumber string
+			// FirstName is the firstName argument value.
+			FirstName string
+			// LastName is the lastName argument value.
+			LastName string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+			// BankName is the bankName argument value.
+			BankName string
+			// BankAccountName is the bankAccountName argument value.
+			BankAccountName string
+			// BankCode is the bankCode argument value.
+			BankCode string
+			// BankAccountNumber is the bankAccountNumber argument value.
+			BankAccountNumber string
+		}
+		// DeliveryBanks holds details about calls to the DeliveryBanks method.
+		DeliveryBanks []struct {
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+		}
+		// DeliveryBanksContext holds details about calls to the DeliveryBanksContext method.
+		DeliveryBanksContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+		}
+		// DeliveryCountries holds details about calls to the DeliveryCountries method.
+		DeliveryCountries []struct {
+		}
+		// DeliveryCountriesContext holds details about calls to the DeliveryCountriesContext method.
+		DeliveryCountriesContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// MomoPayout holds details about calls to the MomoPayout method.
+		MomoPayout []struct {
+			// PayoutToken is the payoutToken argument value.
+			PayoutToken string
+			// PhoneNumber is the phoneNumber argument value.
+			PhoneNumber string
+			// FirstName is the firstName argument value.
+			FirstName string
+			// LastName is the lastName argument value.
+			LastName string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+		}
+		// MomoPayoutContext holds details about calls to the MomoPayoutContext method.
+		MomoPayoutContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PayoutToken is the payoutToken argument value.
+			PayoutToken string
+			// PhoneNumber is the phoneNumber argument value.
+			PhoneNumber string
+			// FirstName is the firstName argument value.
+			FirstName string
+			// LastName is the lastName argument value.
+			LastName string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+		}
+		// Quotation holds details about calls to the Quotation method.
+		Quotation []struct {
+			// SourceWallet is the sourceWallet argument value.
+			SourceWallet string
+			// Amount is the amount argument value.
+			Amount float64
+			// TransactionType is the transactionType argument value.
+			TransactionType string
+			// DestinationCountry is the destinationCountry argument value.
+			DestinationCountry string
+			// DestinationCurrency is the destinationCurrency argument value.
+			DestinationCurrency string
+			// AmountType is the amountType argument value.
+			AmountType string
+		}
+		// QuotationContext holds details about calls to the QuotationContext method.
+		QuotationContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SourceWallet is the sourceWallet argument value.
+			SourceWallet string
+			// Amount is the amount argument value.
+			Amount float64
+			// TransactionType is the transactionType argument value.
+			TransactionType string
+			// DestinationCountry is the destinationCountry argument value.
+			DestinationCountry string
+			// DestinationCurrency is the destinationCurrency argument value.
+			DestinationCurrency string
+			// AmountType is the amountType argument value.
+			AmountType string
+		}
+		// Transaction holds details about calls to the Transaction method.
+		Transaction []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+		// TransactionContext holds details about calls to the TransactionContext method.
+		TransactionContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+	}
+	lockBankPayout               sync.RWMutex
+	lockBankPayoutContext        sync.RWMutex
+	lockDeliveryBanks            sync.RWMutex
+	lockDeliveryBanksContext     sync.RWMutex
+	lockDeliveryCountries        sync.RWMutex
+	lockDeliveryCountriesContext sync.RWMutex
+	lockMomoPayout               sync.RWMutex
+	lockMomoPayoutContext        sync.RWMutex
+	lockQuotation                sync.RWMutex
+	lockQuotationContext         sync.RWMutex
+	lockTransaction              sync.RWMutex
+	lockTransactionContext       sync.RWMutex
+}
+
+// BankPayout calls BankPayoutFunc.
+func (mock *PayoutServiceMock) BankPayout(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error) {
+	if mock.BankPayoutFunc == nil {
+		panic("PayoutServiceMock.BankPayoutFunc: method is nil but PayoutService.BankPayout was just called")
+	}
+	callInfo := struct {
+		PayoutToken       string
+		PhoneNumber       string
+		FirstName         string
+		LastName          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}{
+		PayoutToken:       payoutToken,
+		PhoneNumber:       phoneNumber,
+		FirstName:         firstName,
+		LastName:          lastName,
+		CountryCode:       countryCode,
+		BankName:          bankName,
+		BankAccountName:   bankAccountName,
+		BankCode:          bankCode,
+		BankAccountNumber: bankAccountNumber,
+	}
+	mock.lockBankPayout.Lock()
+	mock.calls.BankPayout = append(mock.calls.BankPayout, callInfo)
+	mock.lockBankPayout.Unlock()
+	return mock.BankPayoutFunc(payoutToken, phoneNumber, firstName, lastName, countryCode, bankName, bankAccountName, bankCode, bankAccountNumber)
+}
+
+// BankPayoutCalls gets all the calls that were made to BankPayout.
+// Check the length with:
+//
+//	len(mockedPayoutService.BankPayoutCalls())
+func (mock *PayoutServiceMock) BankPayoutCalls() []struct {
+	PayoutToken       string
+	PhoneNumber       string
+	FirstName         string
+	LastName          string
+	CountryCode       string
+	BankName          string
+	BankAccountName   string
+	BankCode          string
+	BankAccountNumber string
+} {
+	var calls []struct {
+		PayoutToken       string
+		PhoneNumber       string
+		FirstName         string
+		LastName          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}
+	mock.lockBankPayout.RLock()
+	calls = mock.calls.BankPayout
+	mock.lockBankPayout.RUnlock()
+	return calls
+}
+
+// BankPayoutContext calls BankPayoutContextFunc.
+func (mock *PayoutServiceMock) BankPayoutContext(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error) {
+	if mock.BankPayoutContextFunc == nil {
+		panic("PayoutServiceMock.BankPayoutContextFunc: method is nil but PayoutService.BankPayoutContext was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		PayoutToken       string
+		PhoneNumber       string
+		FirstName         string
+		LastName          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}{
+		Ctx:               ctx,
+		PayoutToken:       payoutToken,
+		PhoneNumber:       phoneNumber,
+		FirstName:         firstName,
+		LastName:          lastName,
+		CountryCode:       countryCode,
+		BankName:          bankName,
+		BankAccountName:   bankAccountName,
+		BankCode:          bankCode,
+		BankAccountNumber: bankAccountNumber,
+	}
+	mock.lockBankPayoutContext.Lock()
+	mock.calls.BankPayoutContext = append(mock.calls.BankPayoutContext, callInfo)
+	mock.lockBankPayoutContext.Unlock()
+	return mock.BankPayoutContextFunc(ctx, payoutToken, phoneNumber, firstName, lastName, countryCode, bankName, bankAccountName, bankCode, bankAccountNumber)
+}
+
+// BankPayoutContextCalls gets all the calls that were made to BankPayoutContext.
+// Check the length with:
+//
+//	len(mockedPayoutService.BankPayoutContextCalls())
+func (mock *PayoutServiceMock) BankPayoutContextCalls() []struct {
+	Ctx               context.Context
+	PayoutToken       string
+	PhoneNumber       string
+	FirstName         string
+	LastName          string
+	CountryCode       string
+	BankName          string
+	BankAccountName   string
+	BankCode          string
+	BankAccountNumber string
+} {
+	var calls []struct {
+		Ctx               context.Context
+		PayoutToken       string
+		PhoneNumber       string
+		FirstName         string
+		LastName          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}
+	mock.lockBankPayoutContext.RLock()
+	calls = mock.calls.BankPayoutContext
+	mock.lockBankPayoutContext.RUnlock()
+	return calls
+}
+
+// DeliveryBanks calls DeliveryBanksFunc.
+func (mock *PayoutServiceMock) DeliveryBanks(countryCode string) ([]interface{}, error) {
+	if mock.DeliveryBanksFunc == nil {
+		panic("PayoutServiceMock.DeliveryBanksFunc: method is nil but PayoutService.DeliveryBanks was just called")
+	}
+	callInfo := struct {
+		CountryCode string
+	}{
+		CountryCode: countryCode,
+	}
+	mock.lockDeliveryBanks.Lock()
+	mock.calls.DeliveryBanks = append(mock.calls.DeliveryBanks, callInfo)
+	mock.lockDeliveryBanks.Unlock()
+	return mock.DeliveryBanksFunc(countryCode)
+}
+
+// DeliveryBanksCalls gets all the calls that were made to DeliveryBanks.
+// Check the length with:
+//
+//	len(mockedPayoutService.DeliveryBanksCalls())
+func (mock *PayoutServiceMock) DeliveryBanksCalls() []struct {
+	CountryCode string
+} {
+	var calls []struct {
+		CountryCode string
+	}
+	mock.lockDeliveryBanks.RLock()
+	calls = mock.calls.DeliveryBanks
+	mock.lockDeliveryBanks.RUnlock()
+	return calls
+}
+
+// DeliveryBanksContext calls DeliveryBanksContextFunc.
+func (mock *PayoutServiceMock) DeliveryBanksContext(ctx context.Context, countryCode string) ([]interface{}, error) {
+	if mock.DeliveryBanksContextFunc == nil {
+		panic("PayoutServiceMock.DeliveryBanksContextFunc: method is nil but PayoutService.DeliveryBanksContext was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		CountryCode string
+	}{
+		Ctx:         ctx,
+		CountryCode: countryCode,
+	}
+	mock.lockDeliveryBanksContext.Lock()
+	mock.calls.DeliveryBanksContext = append(mock.calls.DeliveryBanksContext, callInfo)
+	mock.lockDeliveryBanksContext.Unlock()
+	return mock.DeliveryBanksContextFunc(ctx, countryCode)
+}
+
+// DeliveryBanksContextCalls gets all the calls that were made to DeliveryBanksContext.
+// Check the length with:
+//
+//	len(mockedPayoutService.DeliveryBanksContextCalls())
+func (mock *PayoutServiceMock) DeliveryBanksContextCalls() []struct {
+	Ctx         context.Context
+	CountryCode string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		CountryCode string
+	}
+	mock.lockDeliveryBanksContext.RLock()
+	calls = mock.calls.DeliveryBanksContext
+	mock.lockDeliveryBanksContext.RUnlock()
+	return calls
+}
+
+// DeliveryCountries calls DeliveryCountriesFunc.
+func (mock *PayoutServiceMock) DeliveryCountries() ([]interface{}, error) {
+	if mock.DeliveryCountriesFunc == nil {
+		panic("PayoutServiceMock.DeliveryCountriesFunc: method is nil but PayoutService.DeliveryCountries was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockDeliveryCountries.Lock()
+	mock.calls.DeliveryCountries = append(mock.calls.DeliveryCountries, callInfo)
+	mock.lockDeliveryCountries.Unlock()
+	return mock.DeliveryCountriesFunc()
+}
+
+// DeliveryCountriesCalls gets all the calls that were made to DeliveryCountries.
+// Check the length with:
+//
+//	len(mockedPayoutService.DeliveryCountriesCalls())
+func (mock *PayoutServiceMock) DeliveryCountriesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDeliveryCountries.RLock()
+	calls = mock.calls.DeliveryCountries
+	mock.lockDeliveryCountries.RUnlock()
+	return calls
+}
+
+// DeliveryCountriesContext calls DeliveryCountriesContextFunc.
+func (mock *PayoutServiceMock) DeliveryCountriesContext(ctx context.Context) ([]interface{}, error) {
+	if mock.DeliveryCountriesContextFunc == nil {
+		panic("PayoutServiceMock.DeliveryCountriesContextFunc: method is nil but PayoutService.DeliveryCountriesContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDeliveryCountriesContext.Lock()
+	mock.calls.DeliveryCountriesContext = append(mock.calls.DeliveryCountriesContext, callInfo)
+	mock.lockDeliveryCountriesContext.Unlock()
+	return mock.DeliveryCountriesContextFunc(ctx)
+}
+
+// DeliveryCountriesContextCalls gets all the calls that were made to DeliveryCountriesContext.
+// Check the length with:
+//
+//	len(mockedPayoutService.DeliveryCountriesContextCalls())
+func (mock *PayoutServiceMock) DeliveryCountriesContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockDeliveryCountriesContext.RLock()
+	calls = mock.calls.DeliveryCountriesContext
+	mock.lockDeliveryCountriesContext.RUnlock()
+	return calls
+}
+
+// MomoPayout calls MomoPayoutFunc.
+func (mock *PayoutServiceMock) MomoPayout(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
+	if mock.MomoPayoutFunc == nil {
+		panic("PayoutServiceMock.MomoPayoutFunc: method is nil but PayoutService.MomoPayout was just called")
+	}
+	callInfo := struct {
+		PayoutToken string
+		PhoneNumber string
+		FirstName   string
+		LastName    string
+		CountryCode string
+	}{
+		PayoutToken: payoutToken,
+		PhoneNumber: phoneNumber,
+		FirstName:   firstName,
+		LastName:    lastName,
+		CountryCode: countryCode,
+	}
+	mock.lockMomoPayout.Lock()
+	mock.calls.MomoPayout = append(mock.calls.MomoPayout, callInfo)
+	mock.lockMomoPayout.Unlock()
+	return mock.MomoPayoutFunc(payoutToken, phoneNumber, firstName, lastName, countryCode)
+}
+
+// MomoPayoutCalls gets all the calls that were made to MomoPayout.
+// Check the length with:
+//
+//	len(mockedPayoutService.MomoPayoutCalls())
+func (mock *PayoutServiceMock) MomoPayoutCalls() []struct {
+	PayoutToken string
+	PhoneNumber string
+	FirstName   string
+	LastName    string
+	CountryCode string
+} {
+	var calls []struct {
+		PayoutToken string
+		PhoneNumber string
+		FirstName   string
+		LastName    string
+		CountryCode string
+	}
+	mock.lockMomoPayout.RLock()
+	calls = mock.calls.MomoPayout
+	mock.lockMomoPayout.RUnlock()
+	return calls
+}
+
+// MomoPayoutContext calls MomoPayoutContextFunc.
+func (mock *PayoutServiceMock) MomoPayoutContext(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
+	if mock.MomoPayoutContextFunc == nil {
+		panic("PayoutServiceMock.MomoPayoutContextFunc: method is nil but PayoutService.MomoPayoutContext was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		PayoutToken string
+		PhoneNumber string
+		FirstName   string
+		LastName    string
+		CountryCode string
+	}{
+		Ctx:         ctx,
+		PayoutToken: payoutToken,
+		PhoneNumber: phoneNumber,
+		FirstName:   firstName,
+		LastName:    lastName,
+		CountryCode: countryCode,
+	}
+	mock.lockMomoPayoutContext.Lock()
+	mock.calls.MomoPayoutContext = append(mock.calls.MomoPayoutContext, callInfo)
+	mock.lockMomoPayoutContext.Unlock()
+	return mock.MomoPayoutContextFunc(ctx, payoutToken, phoneNumber, firstName, lastName, countryCode)
+}
+
+// MomoPayoutContextCalls gets all the calls that were made to MomoPayoutContext.
+// Check the length with:
+//
+//	len(mockedPayoutService.MomoPayoutContextCalls())
+func (mock *PayoutServiceMock) MomoPayoutContextCalls() []struct {
+	Ctx         context.Context
+	PayoutToken string
+	PhoneNumber string
+	FirstName   string
+	LastName    string
+	CountryCode string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		PayoutToken string
+		PhoneNumber string
+		FirstName   string
+		LastName    string
+		CountryCode string
+	}
+	mock.lockMomoPayoutContext.RLock()
+	calls = mock.calls.MomoPayoutContext
+	mock.lockMomoPayoutContext.RUnlock()
+	return calls
+}
+
+// Quotation calls QuotationFunc.
+func (mock *PayoutServiceMock) Quotation(sourceWallet string, amount float64, transactionType string, destinationCountry string, destinationCurrency string, amountType string) (map[string]interface{}, error) {
+	if mock.QuotationFunc == nil {
+		panic("PayoutServiceMock.QuotationFunc: method is nil but PayoutService.Quotation was just called")
+	}
+	callInfo := struct {
+		SourceWallet        string
+		Amount              float64
+		TransactionType     string
+		DestinationCountry  string
+		DestinationCurrency string
+		AmountType          string
+	}{
+		SourceWallet:        sourceWallet,
+		Amount:              amount,
+		TransactionType:     transactionType,
+		DestinationCountry:  destinationCountry,
+		DestinationCurrency: destinationCurrency,
+		AmountType:          amountType,
+	}
+	mock.lockQuotation.Lock()
+	mock.calls.Quotation = append(mock.calls.Quotation, callInfo)
+	mock.lockQuotation.Unlock()
+	return mock.QuotationFunc(sourceWallet, amount, transactionType, destinationCountry, destinationCurrency, amountType)
+}
+
+// QuotationCalls gets all the calls that were made to Quotation.
+// Check the length with:
+//
+//	len(mockedPayoutService.QuotationCalls())
+func (mock *PayoutServiceMock) QuotationCalls() []struct {
+	SourceWallet        string
+	Amount              float64
+	TransactionType     string
+	DestinationCountry  string
+	DestinationCurrency string
+	AmountType          string
+} {
+	var calls []struct {
+		SourceWallet        string
+		Amount              float64
+		TransactionType     string
+		DestinationCountry  string
+		DestinationCurrency string
+		AmountType          string
+	}
+	mock.lockQuotation.RLock()
+	calls = mock.calls.Quotation
+	mock.lockQuotation.RUnlock()
+	return calls
+}
+
+// QuotationContext calls QuotationContextFunc.
+func (mock *PayoutServiceMock) QuotationContext(ctx context.Context, sourceWallet string, amount float64, transactionType string, destinationCountry string, destinationCurrency string, amountType string) (map[string]interface{}, error) {
+	if mock.QuotationContextFunc == nil {
+		panic("PayoutServiceMock.QuotationContextFunc: method is nil but PayoutService.QuotationContext was just called")
+	}
+	callInfo := struct {
+		Ctx                 context.Context
+		SourceWallet        string
+		Amount              float64
+		TransactionType     string
+		DestinationCountry  string
+		DestinationCurrency string
+		AmountType          string
+	}{
+		Ctx:                 ctx,
+		SourceWallet:        sourceWallet,
+		Amount:              amount,
+		TransactionType:     transactionType,
+		DestinationCountry:  destinationCountry,
+		DestinationCurrency: destinationCurrency,
+		AmountType:          amountType,
+	}
+	mock.lockQuotationContext.Lock()
+	mock.calls.QuotationContext = append(mock.calls.QuotationContext, callInfo)
+	mock.lockQuotationContext.Unlock()
+	return mock.QuotationContextFunc(ctx, sourceWallet, amount, transactionType, destinationCountry, destinationCurrency, amountType)
+}
+
+// QuotationContextCalls gets all the calls that were made to QuotationContext.
+// Check the length with:
+//
+//	len(mockedPayoutService.QuotationContextCalls())
+func (mock *PayoutServiceMock) QuotationContextCalls() []struct {
+	Ctx                 context.Context
+	SourceWallet        string
+	Amount              float64
+	TransactionType     string
+	DestinationCountry  string
+	DestinationCurrency string
+	AmountType          string
+} {
+	var calls []struct {
+		Ctx                 context.Context
+		SourceWallet        string
+		Amount              float64
+		TransactionType     string
+		DestinationCountry  string
+		DestinationCurrency string
+		AmountType          string
+	}
+	mock.lockQuotationContext.RLock()
+	calls = mock.calls.QuotationContext
+	mock.lockQuotationContext.RUnlock()
+	return calls
+}
+
+// Transaction calls TransactionFunc.
+func (mock *PayoutServiceMock) Transaction(transactionId string) (map[string]interface{}, error) {
+	if mock.TransactionFunc == nil {
+		panic("PayoutServiceMock.TransactionFunc: method is nil but PayoutService.Transaction was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockTransaction.Lock()
+	mock.calls.Transaction = append(mock.calls.Transaction, callInfo)
+	mock.lockTransaction.Unlock()
+	return mock.TransactionFunc(transactionId)
+}
+
+// TransactionCalls gets all the calls that were made to Transaction.
+// Check the length with:
+//
+//	len(mockedPayoutService.TransactionCalls())
+func (mock *PayoutServiceMock) TransactionCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockTransaction.RLock()
+	calls = mock.calls.Transaction
+	mock.lockTransaction.RUnlock()
+	return calls
+}
+
+// TransactionContext calls TransactionContextFunc.
+func (mock *PayoutServiceMock) TransactionContext(ctx context.Context, transactionId string) (map[string]interface{}, error) {
+	if mock.TransactionContextFunc == nil {
+		panic("PayoutServiceMock.TransactionContextFunc: method is nil but PayoutService.TransactionContext was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		TransactionId string
+	}{
+		Ctx:           ctx,
+		TransactionId: transactionId,
+	}
+	mock.lockTransactionContext.Lock()
+	mock.calls.TransactionContext = append(mock.calls.TransactionContext, callInfo)
+	mock.lockTransactionContext.Unlock()
+	return mock.TransactionContextFunc(ctx, transactionId)
+}
+
+// TransactionContextCalls gets all the calls that were made to TransactionContext.
+// Check the length with:
+//
+//	len(mockedPayoutService.TransactionContextCalls())
+func (mock *PayoutServiceMock) TransactionContextCalls() []struct {
+	Ctx           context.Context
+	TransactionId string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		TransactionId string
+	}
+	mock.lockTransactionContext.RLock()
+	calls = mock.calls.TransactionContext
+	mock.lockTransactionContext.RUnlock()
+	return calls
+}
+
+// Ensure, that BeneficiaryServiceMock does implement eversendSdk.BeneficiaryService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.BeneficiaryService = &BeneficiaryServiceMock{}
+
+// BeneficiaryServiceMock is a mock implementation of eversendSdk.BeneficiaryService.
+//
+//	func TestSomethingThatUsesBeneficiaryService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.BeneficiaryService
+//		mockedBeneficiaryService := &BeneficiaryServiceMock{
+//			CreateBankBeneficiaryFunc: func(firstName string, lastname string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the CreateBankBeneficiary method")
+//			},
+//			CreateBankBeneficiaryContextFunc: func(ctx context.Context, firstName string, lastname string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the CreateBankBeneficiaryContext method")
+//			},
+//			CreateMomoBeneficiaryFunc: func(firstName string, lastname string, countryCode string, phoneNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the CreateMomoBeneficiary method")
+//			},
+//			CreateMomoBeneficiaryContextFunc: func(ctx context.Context, firstName string, lastname string, countryCode string, phoneNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the CreateMomoBeneficiaryContext method")
+//			},
+//			DeleteFunc: func(beneficiaryId string) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteContextFunc: func(ctx context.Context, beneficiaryId string) error {
+//				panic("mock out the DeleteContext method")
+//			},
+//			FindFunc: func(beneficiaryId string) (map[string]interface{}, error) {
+//				panic("mock out the Find method")
+//			},
+//			FindContextFunc: func(ctx context.Context, beneficiaryId string) (map[string]interface{}, error) {
+//				panic("mock out the FindContext method")
+//			},
+//			FindDuplicateFunc: func(candidate eversendSdk.BeneficiaryDetails) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the FindDuplicate method")
+//			},
+//			FindDuplicateContextFunc: func(ctx context.Context, candidate eversendSdk.BeneficiaryDetails) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the FindDuplicateContext method")
+//			},
+//			ListFunc: func() ([]interface{}, error) {
+//				panic("mock out the List method")
+//			},
+//			ListContextFunc: func(ctx context.Context) ([]interface{}, error) {
+//				panic("mock out the ListContext method")
+//			},
+//			SearchFunc: func(query eversendSdk.BeneficiarySearch) ([]eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the Search method")
+//			},
+//			SearchContextFunc: func(ctx context.Context, query eversendSdk.BeneficiarySearch) ([]eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the SearchContext method")
+//			},
+//			UpdateFunc: func(beneficiaryId string, update eversendSdk.BeneficiaryUpdate) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the Update method")
+//			},
+//			UpdateContextFunc: func(ctx context.Context, beneficiaryId string, update eversendSdk.BeneficiaryUpdate) (*eversendSdk.BeneficiaryDetails, error) {
+//				panic("mock out the UpdateContext method")
+//			},
+//		}
+//
+//		// use mockedBeneficiaryService in code that requires eversendSdk.BeneficiaryService
+//		// and then make assertions.
+//
+//	}
+type BeneficiaryServiceMock struct {
+	// CreateBankBeneficiaryFunc mocks the CreateBankBeneficiary method.
+	CreateBankBeneficiaryFunc func(firstName string, lastname string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (*eversendSdk.BeneficiaryDetails, error)
+
+	// CreateBankBeneficiaryContextFunc mocks the CreateBankBeneficiaryContext method.
+	CreateBankBeneficiaryContextFunc func(ctx context.Context, firstName string, lastname string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (*eversendSdk.BeneficiaryDetails, error)
+
+	// CreateMomoBeneficiaryFunc mocks the CreateMomoBeneficiary method.
+	CreateMomoBeneficiaryFunc func(firstName string, lastname string, countryCode string, phoneNumber string) (*eversendSdk.BeneficiaryDetails, error)
+
+	// CreateMomoBeneficiaryContextFunc mocks the CreateMomoBeneficiaryContext method.
+	CreateMomoBeneficiaryContextFunc func(ctx context.Context, firstName string, lastname string, countryCode string, phoneNumber string) (*eversendSdk.BeneficiaryDetails, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(beneficiaryId string) error
+
+	// DeleteContextFunc mocks the DeleteContext method.
+	DeleteContextFunc func(ctx context.Context, beneficiaryId string) error
+
+	// FindFunc mocks the Find method.
+	FindFunc func(beneficiaryId string) (map[string]interface{}, error)
+
+	// FindContextFunc mocks the FindContext method.
+	FindContextFunc func(ctx context.Context, beneficiaryId string) (map[string]interface{}, error)
+
+	// FindDuplicateFunc mocks the FindDuplicate method.
+	FindDuplicateFunc func(candidate eversendSdk.BeneficiaryDetails) (*eversendSdk.BeneficiaryDetails, error)
+
+	// FindDuplicateContextFunc mocks the FindDuplicateContext method.
+	FindDuplicateContextFunc func(ctx context.Context, candidate eversendSdk.BeneficiaryDetails) (*eversendSdk.BeneficiaryDetails, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func() ([]interface{}, error)
+
+	// ListContextFunc mocks the ListContext method.
+	ListContextFunc func(ctx context.Context) ([]interface{}, error)
+
+	// SearchFunc mocks the Search method.
+	SearchFunc func(query eversendSdk.BeneficiarySearch) ([]eversendSdk.BeneficiaryDetails, error)
+
+	// SearchContextFunc mocks the SearchContext method.
+	SearchContextFunc func(ctx context.Context, query eversendSdk.BeneficiarySearch) ([]eversendSdk.BeneficiaryDetails, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(beneficiaryId string, update eversendSdk.BeneficiaryUpdate) (*eversendSdk.BeneficiaryDetails, error)
+
+	// UpdateContextFunc mocks the UpdateContext method.
+	UpdateContextFunc func(ctx context.Context, beneficiaryId string, update eversendSdk.BeneficiaryUpdate) (*eversendSdk.BeneficiaryDetails, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateBankBeneficiary holds details about calls to the CreateBankBeneficiary method.
+		CreateBankBeneficiary []struct {
+			// FirstName is the firstName argument value.
+			FirstName string
+			// Lastname is the lastname argument value.
+			Lastname string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+			// BankName is the bankName argument value.
+			BankName string
+			// BankAccountName is the bankAccountName argument value.
+			BankAccountName string
+			// BankCode is the bankCode argument value.
+			BankCode string
+			// BankAccountNumber is the bankAccountNumber argument value.
+			BankAccountNumber string
+		}
+		// CreateBankBeneficiaryContext holds details about calls to the CreateBankBeneficiaryContext method.
+		CreateBankBeneficiaryContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FirstName is the firstName argument value.
+			FirstName string
+			// Lastname is the lastname argument value.
+			Lastname string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+			// BankName is the bankName argument value.
+			BankName string
+			// BankAccountName is the bankAccountName argument value.
+			BankAccountName string
+			// BankCode is the bankCode argument value.
+			BankCode string
+			// BankAccountNumber is the bankAccountNumber argument value.
+			BankAccountNumber string
+		}
+		// CreateMomoBeneficiary holds details about calls to the CreateMomoBeneficiary method.
+		CreateMomoBeneficiary []struct {
+			// FirstName is the firstName argument value.
+			FirstName string
+			// Lastname is the lastname argument value.
+			Lastname string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+			// PhoneNumber is the phoneNumber argument value.
+			PhoneNumber string
+		}
+		// CreateMomoBeneficiaryContext holds details about calls to the CreateMomoBeneficiaryContext method.
+		CreateMomoBeneficiaryContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FirstName is the firstName argument value.
+			FirstName string
+			// Lastname is the lastname argument value.
+			Lastname string
+			// CountryCode is the countryCode argument value.
+			CountryCode string
+			// PhoneNumber is the phoneNumber argument value.
+			PhoneNumber string
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// BeneficiaryId is the beneficiaryId argument value.
+			BeneficiaryId string
+		}
+		// DeleteContext holds details about calls to the DeleteContext method.
+		DeleteContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BeneficiaryId is the beneficiaryId argument value.
+			BeneficiaryId string
+		}
+		// Find holds details about calls to the Find method.
+		Find []struct {
+			// BeneficiaryId is the beneficiaryId argument value.
+			BeneficiaryId string
+		}
+		// FindContext holds details about calls to the FindContext method.
+		FindContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BeneficiaryId is the beneficiaryId argument value.
+			BeneficiaryId string
+		}
+		// FindDuplicate holds details about calls to the FindDuplicate method.
+		FindDuplicate []struct {
+			// Candidate is the candidate argument value.
+			Candidate eversendSdk.BeneficiaryDetails
+		}
+		// FindDuplicateContext holds details about calls to the FindDuplicateContext method.
+		FindDuplicateContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Candidate is the candidate argument value.
+			Candidate eversendSdk.BeneficiaryDetails
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+		}
+		// ListContext holds details about calls to the ListContext method.
+		ListContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Search holds details about calls to the Search method.
+		Search []struct {
+			// Query is the query argument value.
+			Query eversendSdk.BeneficiarySearch
+		}
+		// SearchContext holds details about calls to the SearchContext method.
+		SearchContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query eversendSdk.BeneficiarySearch
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// BeneficiaryId is the beneficiaryId argument value.
+			BeneficiaryId string
+			// Update is the update argument value.
+			Update eversendSdk.BeneficiaryUpdate
+		}
+		// UpdateContext holds details about calls to the UpdateContext method.
+		UpdateContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BeneficiaryId is the beneficiaryId argument value.
+			BeneficiaryId string
+			// Update is the update argument value.
+			Update eversendSdk.BeneficiaryUpdate
+		}
+	}
+	lockCreateBankBeneficiary        sync.RWMutex
+	lockCreateBankBeneficiaryContext sync.RWMutex
+	lockCreateMomoBeneficiary        sync.RWMutex
+	lockCreateMomoBeneficiaryContext sync.RWMutex
+	lockDelete                       sync.RWMutex
+	lockDeleteContext                sync.RWMutex
+	lockFind                         sync.RWMutex
+	lockFindContext                  sync.RWMutex
+	lockFindDuplicate                sync.RWMutex
+	lockFindDuplicateContext         sync.RWMutex
+	lockList                         sync.RWMutex
+	lockListContext                  sync.RWMutex
+	lockSearch                       sync.RWMutex
+	lockSearchContext                sync.RWMutex
+	lockUpdate                       sync.RWMutex
+	lockUpdateContext                sync.RWMutex
+}
+
+// CreateBankBeneficiary calls CreateBankBeneficiaryFunc.
+func (mock *BeneficiaryServiceMock) CreateBankBeneficiary(firstName string, lastname string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.CreateBankBeneficiaryFunc == nil {
+		panic("BeneficiaryServiceMock.CreateBankBeneficiaryFunc: method is nil but BeneficiaryService.CreateBankBeneficiary was just called")
+	}
+	callInfo := struct {
+		FirstName         string
+		Lastname          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}{
+		FirstName:         firstName,
+		Lastname:          lastname,
+		CountryCode:       countryCode,
+		BankName:          bankName,
+		BankAccountName:   bankAccountName,
+		BankCode:          bankCode,
+		BankAccountNumber: bankAccountNumber,
+	}
+	mock.lockCreateBankBeneficiary.Lock()
+	mock.calls.CreateBankBeneficiary = append(mock.calls.CreateBankBeneficiary, callInfo)
+	mock.lockCreateBankBeneficiary.Unlock()
+	return mock.CreateBankBeneficiaryFunc(firstName, lastname, countryCode, bankName, bankAccountName, bankCode, bankAccountNumber)
+}
+
+// CreateBankBeneficiaryCalls gets all the calls that were made to CreateBankBeneficiary.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.CreateBankBeneficiaryCalls())
+func (mock *BeneficiaryServiceMock) CreateBankBeneficiaryCalls() []struct {
+	FirstName         string
+	Lastname          string
+	CountryCode       string
+	BankName          string
+	BankAccountName   string
+	BankCode          string
+	BankAccountNumber string
+} {
+	var calls []struct {
+		FirstName         string
+		Lastname          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}
+	mock.lockCreateBankBeneficiary.RLock()
+	calls = mock.calls.CreateBankBeneficiary
+	mock.lockCreateBankBeneficiary.RUnlock()
+	return calls
+}
+
+// CreateBankBeneficiaryContext calls CreateBankBeneficiaryContextFunc.
+func (mock *BeneficiaryServiceMock) CreateBankBeneficiaryContext(ctx context.Context, firstName string, lastname string, countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.CreateBankBeneficiaryContextFunc == nil {
+		panic("BeneficiaryServiceMock.CreateBankBeneficiaryContextFunc: method is nil but BeneficiaryService.CreateBankBeneficiaryContext was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		FirstName         string
+		Lastname          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}{
+		Ctx:               ctx,
+		FirstName:         firstName,
+		Lastname:          lastname,
+		CountryCode:       countryCode,
+		BankName:          bankName,
+		BankAccountName:   bankAccountName,
+		BankCode:          bankCode,
+		BankAccountNumber: bankAccountNumber,
+	}
+	mock.lockCreateBankBeneficiaryContext.Lock()
+	mock.calls.CreateBankBeneficiaryContext = append(mock.calls.CreateBankBeneficiaryContext, callInfo)
+	mock.lockCreateBankBeneficiaryContext.Unlock()
+	return mock.CreateBankBeneficiaryContextFunc(ctx, firstName, lastname, countryCode, bankName, bankAccountName, bankCode, bankAccountNumber)
+}
+
+// CreateBankBeneficiaryContextCalls gets all the calls that were made to CreateBankBeneficiaryContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.CreateBankBeneficiaryContextCalls())
+func (mock *BeneficiaryServiceMock) CreateBankBeneficiaryContextCalls() []struct {
+	Ctx               context.Context
+	FirstName         string
+	Lastname          string
+	CountryCode       string
+	BankName          string
+	BankAccountName   string
+	BankCode          string
+	BankAccountNumber string
+} {
+	var calls []struct {
+		Ctx               context.Context
+		FirstName         string
+		Lastname          string
+		CountryCode       string
+		BankName          string
+		BankAccountName   string
+		BankCode          string
+		BankAccountNumber string
+	}
+	mock.lockCreateBankBeneficiaryContext.RLock()
+	calls = mock.calls.CreateBankBeneficiaryContext
+	mock.lockCreateBankBeneficiaryContext.RUnlock()
+	return calls
+}
+
+// CreateMomoBeneficiary calls CreateMomoBeneficiaryFunc.
+func (mock *BeneficiaryServiceMock) CreateMomoBeneficiary(firstName string, lastname string, countryCode string, phoneNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.CreateMomoBeneficiaryFunc == nil {
+		panic("BeneficiaryServiceMock.CreateMomoBeneficiaryFunc: method is nil but BeneficiaryService.CreateMomoBeneficiary was just called")
+	}
+	callInfo := struct {
+		FirstName   string
+		Lastname    string
+		CountryCode string
+		PhoneNumber string
+	}{
+		FirstName:   firstName,
+		Lastname:    lastname,
+		CountryCode: countryCode,
+		PhoneNumber: phoneNumber,
+	}
+	mock.lockCreateMomoBeneficiary.Lock()
+	mock.calls.CreateMomoBeneficiary = append(mock.calls.CreateMomoBeneficiary, callInfo)
+	mock.lockCreateMomoBeneficiary.Unlock()
+	return mock.CreateMomoBeneficiaryFunc(firstName, lastname, countryCode, phoneNumber)
+}
+
+// CreateMomoBeneficiaryCalls gets all the calls that were made to CreateMomoBeneficiary.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.CreateMomoBeneficiaryCalls())
+func (mock *BeneficiaryServiceMock) CreateMomoBeneficiaryCalls() []struct {
+	FirstName   string
+	Lastname    string
+	CountryCode string
+	PhoneNumber string
+} {
+	var calls []struct {
+		FirstName   string
+		Lastname    string
+		CountryCode string
+		PhoneNumber string
+	}
+	mock.lockCreateMomoBeneficiary.RLock()
+	calls = mock.calls.CreateMomoBeneficiary
+	mock.lockCreateMomoBeneficiary.RUnlock()
+	return calls
+}
+
+// CreateMomoBeneficiaryContext calls CreateMomoBeneficiaryContextFunc.
+func (mock *BeneficiaryServiceMock) CreateMomoBeneficiaryContext(ctx context.Context, firstName string, lastname string, countryCode string, phoneNumber string) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.CreateMomoBeneficiaryContextFunc == nil {
+		panic("BeneficiaryServiceMock.CreateMomoBeneficiaryContextFunc: method is nil but BeneficiaryService.CreateMomoBeneficiaryContext was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		FirstName   string
+		Lastname    string
+		CountryCode string
+		PhoneNumber string
+	}{
+		Ctx:         ctx,
+		FirstName:   firstName,
+		Lastname:    lastname,
+		CountryCode: countryCode,
+		PhoneNumber: phoneNumber,
+	}
+	mock.lockCreateMomoBeneficiaryContext.Lock()
+	mock.calls.CreateMomoBeneficiaryContext = append(mock.calls.CreateMomoBeneficiaryContext, callInfo)
+	mock.lockCreateMomoBeneficiaryContext.Unlock()
+	return mock.CreateMomoBeneficiaryContextFunc(ctx, firstName, lastname, countryCode, phoneNumber)
+}
+
+// CreateMomoBeneficiaryContextCalls gets all the calls that were made to CreateMomoBeneficiaryContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.CreateMomoBeneficiaryContextCalls())
+func (mock *BeneficiaryServiceMock) CreateMomoBeneficiaryContextCalls() []struct {
+	Ctx         context.Context
+	FirstName   string
+	Lastname    string
+	CountryCode string
+	PhoneNumber string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		FirstName   string
+		Lastname    string
+		CountryCode string
+		PhoneNumber string
+	}
+	mock.lockCreateMomoBeneficiaryContext.RLock()
+	calls = mock.calls.CreateMomoBeneficiaryContext
+	mock.lockCreateMomoBeneficiaryContext.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *BeneficiaryServiceMock) Delete(beneficiaryId string) error {
+	if mock.DeleteFunc == nil {
+		panic("BeneficiaryServiceMock.DeleteFunc: method is nil but BeneficiaryService.Delete was just called")
+	}
+	callInfo := struct {
+		BeneficiaryId string
+	}{
+		BeneficiaryId: beneficiaryId,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(beneficiaryId)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.DeleteCalls())
+func (mock *BeneficiaryServiceMock) DeleteCalls() []struct {
+	BeneficiaryId string
+} {
+	var calls []struct {
+		BeneficiaryId string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteContext calls DeleteContextFunc.
+func (mock *BeneficiaryServiceMock) DeleteContext(ctx context.Context, beneficiaryId string) error {
+	if mock.DeleteContextFunc == nil {
+		panic("BeneficiaryServiceMock.DeleteContextFunc: method is nil but BeneficiaryService.DeleteContext was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BeneficiaryId string
+	}{
+		Ctx:           ctx,
+		BeneficiaryId: beneficiaryId,
+	}
+	mock.lockDeleteContext.Lock()
+	mock.calls.DeleteContext = append(mock.calls.DeleteContext, callInfo)
+	mock.lockDeleteContext.Unlock()
+	return mock.DeleteContextFunc(ctx, beneficiaryId)
+}
+
+// DeleteContextCalls gets all the calls that were made to DeleteContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.DeleteContextCalls())
+func (mock *BeneficiaryServiceMock) DeleteContextCalls() []struct {
+	Ctx           context.Context
+	BeneficiaryId string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BeneficiaryId string
+	}
+	mock.lockDeleteContext.RLock()
+	calls = mock.calls.DeleteContext
+	mock.lockDeleteContext.RUnlock()
+	return calls
+}
+
+// Find calls FindFunc.
+func (mock *BeneficiaryServiceMock) Find(beneficiaryId string) (map[string]interface{}, error) {
+	if mock.FindFunc == nil {
+		panic("BeneficiaryServiceMock.FindFunc: method is nil but BeneficiaryService.Find was just called")
+	}
+	callInfo := struct {
+		BeneficiaryId string
+	}{
+		BeneficiaryId: beneficiaryId,
+	}
+	mock.lockFind.Lock()
+	mock.calls.Find = append(mock.calls.Find, callInfo)
+	mock.lockFind.Unlock()
+	return mock.FindFunc(beneficiaryId)
+}
+
+// FindCalls gets all the calls that were made to Find.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.FindCalls())
+func (mock *BeneficiaryServiceMock) FindCalls() []struct {
+	BeneficiaryId string
+} {
+	var calls []struct {
+		BeneficiaryId string
+	}
+	mock.lockFind.RLock()
+	calls = mock.calls.Find
+	mock.lockFind.RUnlock()
+	return calls
+}
+
+// FindContext calls FindContextFunc.
+func (mock *BeneficiaryServiceMock) FindContext(ctx context.Context, beneficiaryId string) (map[string]interface{}, error) {
+	if mock.FindContextFunc == nil {
+		panic("BeneficiaryServiceMock.FindContextFunc: method is nil but BeneficiaryService.FindContext was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BeneficiaryId string
+	}{
+		Ctx:           ctx,
+		BeneficiaryId: beneficiaryId,
+	}
+	mock.lockFindContext.Lock()
+	mock.calls.FindContext = append(mock.calls.FindContext, callInfo)
+	mock.lockFindContext.Unlock()
+	return mock.FindContextFunc(ctx, beneficiaryId)
+}
+
+// FindContextCalls gets all the calls that were made to FindContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.FindContextCalls())
+func (mock *BeneficiaryServiceMock) FindContextCalls() []struct {
+	Ctx           context.Context
+	BeneficiaryId string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BeneficiaryId string
+	}
+	mock.lockFindContext.RLock()
+	calls = mock.calls.FindContext
+	mock.lockFindContext.RUnlock()
+	return calls
+}
+
+// FindDuplicate calls FindDuplicateFunc.
+func (mock *BeneficiaryServiceMock) FindDuplicate(candidate eversendSdk.BeneficiaryDetails) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.FindDuplicateFunc == nil {
+		panic("BeneficiaryServiceMock.FindDuplicateFunc: method is nil but BeneficiaryService.FindDuplicate was just called")
+	}
+	callInfo := struct {
+		Candidate eversendSdk.BeneficiaryDetails
+	}{
+		Candidate: candidate,
+	}
+	mock.lockFindDuplicate.Lock()
+	mock.calls.FindDuplicate = append(mock.calls.FindDuplicate, callInfo)
+	mock.lockFindDuplicate.Unlock()
+	return mock.FindDuplicateFunc(candidate)
+}
+
+// FindDuplicateCalls gets all the calls that were made to FindDuplicate.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.FindDuplicateCalls())
+func (mock *BeneficiaryServiceMock) FindDuplicateCalls() []struct {
+	Candidate eversendSdk.BeneficiaryDetails
+} {
+	var calls []struct {
+		Candidate eversendSdk.BeneficiaryDetails
+	}
+	mock.lockFindDuplicate.RLock()
+	calls = mock.calls.FindDuplicate
+	mock.lockFindDuplicate.RUnlock()
+	return calls
+}
+
+// FindDuplicateContext calls FindDuplicateContextFunc.
+func (mock *BeneficiaryServiceMock) FindDuplicateContext(ctx context.Context, candidate eversendSdk.BeneficiaryDetails) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.FindDuplicateContextFunc == nil {
+		panic("BeneficiaryServiceMock.FindDuplicateContextFunc: method is nil but BeneficiaryService.FindDuplicateContext was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Candidate eversendSdk.BeneficiaryDetails
+	}{
+		Ctx:       ctx,
+		Candidate: candidate,
+	}
+	mock.lockFindDuplicateContext.Lock()
+	mock.calls.FindDuplicateContext = append(mock.calls.FindDuplicateContext, callInfo)
+	mock.lockFindDuplicateContext.Unlock()
+	return mock.FindDuplicateContextFunc(ctx, candidate)
+}
+
+// FindDuplicateContextCalls gets all the calls that were made to FindDuplicateContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.FindDuplicateContextCalls())
+func (mock *BeneficiaryServiceMock) FindDuplicateContextCalls() []struct {
+	Ctx       context.Context
+	Candidate eversendSdk.BeneficiaryDetails
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Candidate eversendSdk.BeneficiaryDetails
+	}
+	mock.lockFindDuplicateContext.RLock()
+	calls = mock.calls.FindDuplicateContext
+	mock.lockFindDuplicateContext.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *BeneficiaryServiceMock) List() ([]interface{}, error) {
+	if mock.ListFunc == nil {
+		panic("BeneficiaryServiceMock.ListFunc: method is nil but BeneficiaryService.List was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc()
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.ListCalls())
+func (mock *BeneficiaryServiceMock) ListCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ListContext calls ListContextFunc.
+func (mock *BeneficiaryServiceMock) ListContext(ctx context.Context) ([]interface{}, error) {
+	if mock.ListContextFunc == nil {
+		panic("BeneficiaryServiceMock.ListContextFunc: method is nil but BeneficiaryService.ListContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListContext.Lock()
+	mock.calls.ListContext = append(mock.calls.ListContext, callInfo)
+	mock.lockListContext.Unlock()
+	return mock.ListContextFunc(ctx)
+}
+
+// ListContextCalls gets all the calls that were made to ListContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.ListContextCalls())
+func (mock *BeneficiaryServiceMock) ListContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListContext.RLock()
+	calls = mock.calls.ListContext
+	mock.lockListContext.RUnlock()
+	return calls
+}
+
+// Search calls SearchFunc.
+func (mock *BeneficiaryServiceMock) Search(query eversendSdk.BeneficiarySearch) ([]eversendSdk.BeneficiaryDetails, error) {
+	if mock.SearchFunc == nil {
+		panic("BeneficiaryServiceMock.SearchFunc: method is nil but BeneficiaryService.Search was just called")
+	}
+	callInfo := struct {
+		Query eversendSdk.BeneficiarySearch
+	}{
+		Query: query,
+	}
+	mock.lockSearch.Lock()
+	mock.calls.Search = append(mock.calls.Search, callInfo)
+	mock.lockSearch.Unlock()
+	return mock.SearchFunc(query)
+}
+
+// SearchCalls gets all the calls that were made to Search.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.SearchCalls())
+func (mock *BeneficiaryServiceMock) SearchCalls() []struct {
+	Query eversendSdk.BeneficiarySearch
+} {
+	var calls []struct {
+		Query eversendSdk.BeneficiarySearch
+	}
+	mock.lockSearch.RLock()
+	calls = mock.calls.Search
+	mock.lockSearch.RUnlock()
+	return calls
+}
+
+// SearchContext calls SearchContextFunc.
+func (mock *BeneficiaryServiceMock) SearchContext(ctx context.Context, query eversendSdk.BeneficiarySearch) ([]eversendSdk.BeneficiaryDetails, error) {
+	if mock.SearchContextFunc == nil {
+		panic("BeneficiaryServiceMock.SearchContextFunc: method is nil but BeneficiaryService.SearchContext was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Query eversendSdk.BeneficiarySearch
+	}{
+		Ctx:   ctx,
+		Query: query,
+	}
+	mock.lockSearchContext.Lock()
+	mock.calls.SearchContext = append(mock.calls.SearchContext, callInfo)
+	mock.lockSearchContext.Unlock()
+	return mock.SearchContextFunc(ctx, query)
+}
+
+// SearchContextCalls gets all the calls that were made to SearchContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.SearchContextCalls())
+func (mock *BeneficiaryServiceMock) SearchContextCalls() []struct {
+	Ctx   context.Context
+	Query eversendSdk.BeneficiarySearch
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Query eversendSdk.BeneficiarySearch
+	}
+	mock.lockSearchContext.RLock()
+	calls = mock.calls.SearchContext
+	mock.lockSearchContext.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *BeneficiaryServiceMock) Update(beneficiaryId string, update eversendSdk.BeneficiaryUpdate) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.UpdateFunc == nil {
+		panic("BeneficiaryServiceMock.UpdateFunc: method is nil but BeneficiaryService.Update was just called")
+	}
+	callInfo := struct {
+		BeneficiaryId string
+		Update        eversendSdk.BeneficiaryUpdate
+	}{
+		BeneficiaryId: beneficiaryId,
+		Update:        update,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(beneficiaryId, update)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.UpdateCalls())
+func (mock *BeneficiaryServiceMock) UpdateCalls() []struct {
+	BeneficiaryId string
+	Update        eversendSdk.BeneficiaryUpdate
+} {
+	var calls []struct {
+		BeneficiaryId string
+		Update        eversendSdk.BeneficiaryUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// UpdateContext calls UpdateContextFunc.
+func (mock *BeneficiaryServiceMock) UpdateContext(ctx context.Context, beneficiaryId string, update eversendSdk.BeneficiaryUpdate) (*eversendSdk.BeneficiaryDetails, error) {
+	if mock.UpdateContextFunc == nil {
+		panic("BeneficiaryServiceMock.UpdateContextFunc: method is nil but BeneficiaryService.UpdateContext was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BeneficiaryId string
+		Update        eversendSdk.BeneficiaryUpdate
+	}{
+		Ctx:           ctx,
+		BeneficiaryId: beneficiaryId,
+		Update:        update,
+	}
+	mock.lockUpdateContext.Lock()
+	mock.calls.UpdateContext = append(mock.calls.UpdateContext, callInfo)
+	mock.lockUpdateContext.Unlock()
+	return mock.UpdateContextFunc(ctx, beneficiaryId, update)
+}
+
+// UpdateContextCalls gets all the calls that were made to UpdateContext.
+// Check the length with:
+//
+//	len(mockedBeneficiaryService.UpdateContextCalls())
+func (mock *BeneficiaryServiceMock) UpdateContextCalls() []struct {
+	Ctx           context.Context
+	BeneficiaryId string
+	Update        eversendSdk.BeneficiaryUpdate
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BeneficiaryId string
+		Update        eversendSdk.BeneficiaryUpdate
+	}
+	mock.lockUpdateContext.RLock()
+	calls = mock.calls.UpdateContext
+	mock.lockUpdateContext.RUnlock()
+	return calls
+}
+
+// Ensure, that CryptoServiceMock does implement eversendSdk.CryptoService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.CryptoService = &CryptoServiceMock{}
+
+// CryptoServiceMock is a mock implementation of eversendSdk.CryptoService.
+//
+//	func TestSomethingThatUsesCryptoService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.CryptoService
+//		mockedCryptoService := &CryptoServiceMock{
+//			AddressTransactionsFunc: func(cryptoCoinAddress string) ([]eversendSdk.CryptoTransaction, error) {
+//				panic("mock out the AddressTransactions method")
+//			},
+//			AddressTransactionsContextFunc: func(ctx context.Context, cryptoCoinAddress string) ([]eversendSdk.CryptoTransaction, error) {
+//				panic("mock out the AddressTransactionsContext method")
+//			},
+//			AddressesFunc: func() ([]eversendSdk.CryptoAddress, error) {
+//				panic("mock out the Addresses method")
+//			},
+//			AddressesContextFunc: func(ctx context.Context) ([]eversendSdk.CryptoAddress, error) {
+//				panic("mock out the AddressesContext method")
+//			},
+//			AssetChainsFunc: func(coin string) (*eversendSdk.CryptoAsset, error) {
+//				panic("mock out the AssetChains method")
+//			},
+//			AssetChainsContextFunc: func(ctx context.Context, coin string) (*eversendSdk.CryptoAsset, error) {
+//				panic("mock out the AssetChainsContext method")
+//			},
+//			CreateAddressFunc: func(assetId string, ownerName string, destinationAddressDescription string, purpose string) (*eversendSdk.CryptoAddress, error) {
+//				panic("mock out the CreateAddress method")
+//			},
+//			CreateAddressContextFunc: func(ctx context.Context, assetId string, ownerName string, destinationAddressDescription string, purpose string) (*eversendSdk.CryptoAddress, error) {
+//				panic("mock out the CreateAddressContext method")
+//			},
+//			FindAddressFunc: func(address string) (*eversendSdk.CryptoAddress, error) {
+//				panic("mock out the FindAddress method")
+//			},
+//			FindAddressContextFunc: func(ctx context.Context, address string) (*eversendSdk.CryptoAddress, error) {
+//				panic("mock out the FindAddressContext method")
+//			},
+//			SearchAddressesFunc: func(query eversendSdk.AddressSearch) ([]eversendSdk.CryptoAddress, error) {
+//				panic("mock out the SearchAddresses method")
+//			},
+//			SearchAddressesContextFunc: func(ctx context.Context, query eversendSdk.AddressSearch) ([]eversendSdk.CryptoAddress, error) {
+//				panic("mock out the SearchAddressesContext method")
+//			},
+//			TransactionsFunc: func() ([]eversendSdk.CryptoTransaction, error) {
+//				panic("mock out the Transactions method")
+//			},
+//			TransactionsContextFunc: func(ctx context.Context) ([]eversendSdk.CryptoTransaction, error) {
+//				panic("mock out the TransactionsContext method")
+//			},
+//		}
+//
+//		// use mockedCryptoService in code that requires eversendSdk.CryptoService
+//		// and then make assertions.
+//
+//	}
+type CryptoServiceMock struct {
+	// AddressTransactionsFunc mocks the AddressTransactions method.
+	AddressTransactionsFunc func(cryptoCoinAddress string) ([]eversendSdk.CryptoTransaction, error)
+
+	// AddressTransactionsContextFunc mocks the AddressTransactionsContext method.
+	AddressTransactionsContextFunc func(ctx context.Context, cryptoCoinAddress string) ([]eversendSdk.CryptoTransaction, error)
+
+	// AddressesFunc mocks the Addresses method.
+	AddressesFunc func() ([]eversendSdk.CryptoAddress, error)
+
+	// AddressesContextFunc mocks the AddressesContext method.
+	AddressesContextFunc func(ctx context.Context) ([]eversendSdk.CryptoAddress, error)
+
+	// AssetChainsFunc mocks the AssetChains method.
+	AssetChainsFunc func(coin string) (*eversendSdk.CryptoAsset, error)
+
+	// AssetChainsContextFunc mocks the AssetChainsContext method.
+	AssetChainsContextFunc func(ctx context.Context, coin string) (*eversendSdk.CryptoAsset, error)
+
+	// CreateAddressFunc mocks the CreateAddress method.
+	CreateAddressFunc func(assetId string, ownerName string, destinationAddressDescription string, purpose string) (*eversendSdk.CryptoAddress, error)
+
+	// CreateAddressContextFunc mocks the CreateAddressContext method.
+	CreateAddressContextFunc func(ctx context.Context, assetId string, ownerName string, destinationAddressDescription string, purpose string) (*eversendSdk.CryptoAddress, error)
+
+	// FindAddressFunc mocks the FindAddress method.
+	FindAddressFunc func(address string) (*eversendSdk.CryptoAddress, error)
+
+	// FindAddressContextFunc mocks the FindAddressContext method.
+	FindAddressContextFunc func(ctx context.Context, address string) (*eversendSdk.CryptoAddress, error)
+
+	// SearchAddressesFunc mocks the SearchAddresses method.
+	SearchAddressesFunc func(query eversendSdk.AddressSearch) ([]eversendSdk.CryptoAddress, error)
+
+	// SearchAddressesContextFunc mocks the SearchAddressesContext method.
+	SearchAddressesContextFunc func(ctx context.Context, query eversendSdk.AddressSearch) ([]eversendSdk.CryptoAddress, error)
+
+	// TransactionsFunc mocks the Transactions method.
+	TransactionsFunc func() ([]eversendSdk.CryptoTransaction, error)
+
+	// TransactionsContextFunc mocks the TransactionsContext method.
+	TransactionsContextFunc func(ctx context.Context) ([]eversendSdk.CryptoTransaction, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddressTransactions holds details about calls to the AddressTransactions method.
+		AddressTransactions []struct {
+			// CryptoCoinAddress is the cryptoCoinAddress argument value.
+			CryptoCoinAddress string
+		}
+		// AddressTransactionsContext holds details about calls to the AddressTransactionsContext method.
+		AddressTransactionsContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CryptoCoinAddress is the cryptoCoinAddress argument value.
+			CryptoCoinAddress string
+		}
+		// Addresses holds details about calls to the Addresses method.
+		Addresses []struct {
+		}
+		// AddressesContext holds details about calls to the AddressesContext method.
+		AddressesContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// AssetChains holds details about calls to the AssetChains method.
+		AssetChains []struct {
+			// Coin is the coin argument value.
+			Coin string
+		}
+		// AssetChainsContext holds details about calls to the AssetChainsContext method.
+		AssetChainsContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Coin is the coin argument value.
+			Coin string
+		}
+		// CreateAddress holds details about calls to the CreateAddress method.
+		CreateAddress []struct {
+			// AssetId is the assetId argument value.
+			AssetId string
+			// OwnerName is the ownerName argument value.
+			OwnerName string
+			// DestinationAddressDescription is the destinationAddressDescription argument value.
+			DestinationAddressDescription string
+			// Purpose is the purpose argument value.
+			Purpose string
+		}
+		// CreateAddressContext holds details about calls to the CreateAddressContext method.
+		CreateAddressContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AssetId is the assetId argument value.
+			AssetId string
+			// OwnerName is the ownerName argument value.
+			OwnerName string
+			// DestinationAddressDescription is the destinationAddressDescription argument value.
+			DestinationAddressDescription string
+			// Purpose is the purpose argument value.
+			Purpose string
+		}
+		// FindAddress holds details about calls to the FindAddress method.
+		FindAddress []struct {
+			// Address is the address argument value.
+			Address string
+		}
+		// FindAddressContext holds details about calls to the FindAddressContext method.
+		FindAddressContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Address is the address argument value.
+			Address string
+		}
+		// SearchAddresses holds details about calls to the SearchAddresses method.
+		SearchAddresses []struct {
+			// Query is the query argument value.
+			Query eversendSdk.AddressSearch
+		}
+		// SearchAddressesContext holds details about calls to the SearchAddressesContext method.
+		SearchAddressesContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query eversendSdk.AddressSearch
+		}
+		// Transactions holds details about calls to the Transactions method.
+		Transactions []struct {
+		}
+		// TransactionsContext holds details about calls to the TransactionsContext method.
+		TransactionsContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockAddressTransactions        sync.RWMutex
+	lockAddressTransactionsContext sync.RWMutex
+	lockAddresses                  sync.RWMutex
+	lockAddressesContext           sync.RWMutex
+	lockAssetChains                sync.RWMutex
+	lockAssetChainsContext         sync.RWMutex
+	lockCreateAddress              sync.RWMutex
+	lockCreateAddressContext       sync.RWMutex
+	lockFindAddress                sync.RWMutex
+	lockFindAddressContext         sync.RWMutex
+	lockSearchAddresses            sync.RWMutex
+	lockSearchAddressesContext     sync.RWMutex
+	lockTransactions               sync.RWMutex
+	lockTransactionsContext        sync.RWMutex
+}
+
+// AddressTransactions calls AddressTransactionsFunc.
+func (mock *CryptoServiceMock) AddressTransactions(cryptoCoinAddress string) ([]eversendSdk.CryptoTransaction, error) {
+	if mock.AddressTransactionsFunc == nil {
+		panic("CryptoServiceMock.AddressTransactionsFunc: method is nil but CryptoService.AddressTransactions was just called")
+	}
+	callInfo := struct {
+		CryptoCoinAddress string
+	}{
+		CryptoCoinAddress: cryptoCoinAddress,
+	}
+	mock.lockAddressTransactions.Lock()
+	mock.calls.AddressTransactions = append(mock.calls.AddressTransactions, callInfo)
+	mock.lockAddressTransactions.Unlock()
+	return mock.AddressTransactionsFunc(cryptoCoinAddress)
+}
+
+// AddressTransactionsCalls gets all the calls that were made to AddressTransactions.
+// Check the length with:
+//
+//	len(mockedCryptoService.AddressTransactionsCalls())
+func (mock *CryptoServiceMock) AddressTransactionsCalls() []struct {
+	CryptoCoinAddress string
+} {
+	var calls []struct {
+		CryptoCoinAddress string
+	}
+	mock.lockAddressTransactions.RLock()
+	calls = mock.calls.AddressTransactions
+	mock.lockAddressTransactions.RUnlock()
+	return calls
+}
+
+// AddressTransactionsContext calls AddressTransactionsContextFunc.
+func (mock *CryptoServiceMock) AddressTransactionsContext(ctx context.Context, cryptoCoinAddress string) ([]eversendSdk.CryptoTransaction, error) {
+	if mock.AddressTransactionsContextFunc == nil {
+		panic("CryptoServiceMock.AddressTransactionsContextFunc: method is nil but CryptoService.AddressTransactionsContext was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		CryptoCoinAddress string
+	}{
+		Ctx:               ctx,
+		CryptoCoinAddress: cryptoCoinAddress,
+	}
+	mock.lockAddressTransactionsContext.Lock()
+	mock.calls.AddressTransactionsContext = append(mock.calls.AddressTransactionsContext, callInfo)
+	mock.lockAddressTransactionsContext.Unlock()
+	return mock.AddressTransactionsContextFunc(ctx, cryptoCoinAddress)
+}
+
+// AddressTransactionsContextCalls gets all the calls that were made to AddressTransactionsContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.AddressTransactionsContextCalls())
+func (mock *CryptoServiceMock) AddressTransactionsContextCalls() []struct {
+	Ctx               context.Context
+	CryptoCoinAddress string
+} {
+	var calls []struct {
+		Ctx               context.Context
+		CryptoCoinAddress string
+	}
+	mock.lockAddressTransactionsContext.RLock()
+	calls = mock.calls.AddressTransactionsContext
+	mock.lockAddressTransactionsContext.RUnlock()
+	return calls
+}
+
+// Addresses calls AddressesFunc.
+func (mock *CryptoServiceMock) Addresses() ([]eversendSdk.CryptoAddress, error) {
+	if mock.AddressesFunc == nil {
+		panic("CryptoServiceMock.AddressesFunc: method is nil but CryptoService.Addresses was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockAddresses.Lock()
+	mock.calls.Addresses = append(mock.calls.Addresses, callInfo)
+	mock.lockAddresses.Unlock()
+	return mock.AddressesFunc()
+}
+
+// AddressesCalls gets all the calls that were made to Addresses.
+// Check the length with:
+//
+//	len(mockedCryptoService.AddressesCalls())
+func (mock *CryptoServiceMock) AddressesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockAddresses.RLock()
+	calls = mock.calls.Addresses
+	mock.lockAddresses.RUnlock()
+	return calls
+}
+
+// AddressesContext calls AddressesContextFunc.
+func (mock *CryptoServiceMock) AddressesContext(ctx context.Context) ([]eversendSdk.CryptoAddress, error) {
+	if mock.AddressesContextFunc == nil {
+		panic("CryptoServiceMock.AddressesContextFunc: method is nil but CryptoService.AddressesContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockAddressesContext.Lock()
+	mock.calls.AddressesContext = append(mock.calls.AddressesContext, callInfo)
+	mock.lockAddressesContext.Unlock()
+	return mock.AddressesContextFunc(ctx)
+}
+
+// AddressesContextCalls gets all the calls that were made to AddressesContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.AddressesContextCalls())
+func (mock *CryptoServiceMock) AddressesContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockAddressesContext.RLock()
+	calls = mock.calls.AddressesContext
+	mock.lockAddressesContext.RUnlock()
+	return calls
+}
+
+// AssetChains calls AssetChainsFunc.
+func (mock *CryptoServiceMock) AssetChains(coin string) (*eversendSdk.CryptoAsset, error) {
+	if mock.AssetChainsFunc == nil {
+		panic("CryptoServiceMock.AssetChainsFunc: method is nil but CryptoService.AssetChains was just called")
+	}
+	callInfo := struct {
+		Coin string
+	}{
+		Coin: coin,
+	}
+	mock.lockAssetChains.Lock()
+	mock.calls.AssetChains = append(mock.calls.AssetChains, callInfo)
+	mock.lockAssetChains.Unlock()
+	return mock.AssetChainsFunc(coin)
+}
+
+// AssetChainsCalls gets all the calls that were made to AssetChains.
+// Check the length with:
+//
+//	len(mockedCryptoService.AssetChainsCalls())
+func (mock *CryptoServiceMock) AssetChainsCalls() []struct {
+	Coin string
+} {
+	var calls []struct {
+		Coin string
+	}
+	mock.lockAssetChains.RLock()
+	calls = mock.calls.AssetChains
+	mock.lockAssetChains.RUnlock()
+	return calls
+}
+
+// AssetChainsContext calls AssetChainsContextFunc.
+func (mock *CryptoServiceMock) AssetChainsContext(ctx context.Context, coin string) (*eversendSdk.CryptoAsset, error) {
+	if mock.AssetChainsContextFunc == nil {
+		panic("CryptoServiceMock.AssetChainsContextFunc: method is nil but CryptoService.AssetChainsContext was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Coin string
+	}{
+		Ctx:  ctx,
+		Coin: coin,
+	}
+	mock.lockAssetChainsContext.Lock()
+	mock.calls.AssetChainsContext = append(mock.calls.AssetChainsContext, callInfo)
+	mock.lockAssetChainsContext.Unlock()
+	return mock.AssetChainsContextFunc(ctx, coin)
+}
+
+// AssetChainsContextCalls gets all the calls that were made to AssetChainsContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.AssetChainsContextCalls())
+func (mock *CryptoServiceMock) AssetChainsContextCalls() []struct {
+	Ctx  context.Context
+	Coin string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Coin string
+	}
+	mock.lockAssetChainsContext.RLock()
+	calls = mock.calls.AssetChainsContext
+	mock.lockAssetChainsContext.RUnlock()
+	return calls
+}
+
+// CreateAddress calls CreateAddressFunc.
+func (mock *CryptoServiceMock) CreateAddress(assetId string, ownerName string, destinationAddressDescription string, purpose string) (*eversendSdk.CryptoAddress, error) {
+	if mock.CreateAddressFunc == nil {
+		panic("CryptoServiceMock.CreateAddressFunc: method is nil but CryptoService.CreateAddress was just called")
+	}
+	callInfo := struct {
+		AssetId                       string
+		OwnerName                     string
+		DestinationAddressDescription string
+		Purpose                       string
+	}{
+		AssetId:                       assetId,
+		OwnerName:                     ownerName,
+		DestinationAddressDescription: destinationAddressDescription,
+		Purpose:                       purpose,
+	}
+	mock.lockCreateAddress.Lock()
+	mock.calls.CreateAddress = append(mock.calls.CreateAddress, callInfo)
+	mock.lockCreateAddress.Unlock()
+	return mock.CreateAddressFunc(assetId, ownerName, destinationAddressDescription, purpose)
+}
+
+// CreateAddressCalls gets all the calls that were made to CreateAddress.
+// Check the length with:
+//
+//	len(mockedCryptoService.CreateAddressCalls())
+func (mock *CryptoServiceMock) CreateAddressCalls() []struct {
+	AssetId                       string
+	OwnerName                     string
+	DestinationAddressDescription string
+	Purpose                       string
+} {
+	var calls []struct {
+		AssetId                       string
+		OwnerName                     string
+		DestinationAddressDescription string
+		Purpose                       string
+	}
+	mock.lockCreateAddress.RLock()
+	calls = mock.calls.CreateAddress
+	mock.lockCreateAddress.RUnlock()
+	return calls
+}
+
+// CreateAddressContext calls CreateAddressContextFunc.
+func (mock *CryptoServiceMock) CreateAddressContext(ctx context.Context, assetId string, ownerName string, destinationAddressDescription string, purpose string) (*eversendSdk.CryptoAddress, error) {
+	if mock.CreateAddressContextFunc == nil {
+		panic("CryptoServiceMock.CreateAddressContextFunc: method is nil but CryptoService.CreateAddressContext was just called")
+	}
+	callInfo := struct {
+		Ctx                           context.Context
+		AssetId                       string
+		OwnerName                     string
+		DestinationAddressDescription string
+		Purpose                       string
+	}{
+		Ctx:                           ctx,
+		AssetId:                       assetId,
+		OwnerName:                     ownerName,
+		DestinationAddressDescription: destinationAddressDescription,
+		Purpose:                       purpose,
+	}
+	mock.lockCreateAddressContext.Lock()
+	mock.calls.CreateAddressContext = append(mock.calls.CreateAddressContext, callInfo)
+	mock.lockCreateAddressContext.Unlock()
+	return mock.CreateAddressContextFunc(ctx, assetId, ownerName, destinationAddressDescription, purpose)
+}
+
+// CreateAddressContextCalls gets all the calls that were made to CreateAddressContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.CreateAddressContextCalls())
+func (mock *CryptoServiceMock) CreateAddressContextCalls() []struct {
+	Ctx                           context.Context
+	AssetId                       string
+	OwnerName                     string
+	DestinationAddressDescription string
+	Purpose                       string
+} {
+	var calls []struct {
+		Ctx                           context.Context
+		AssetId                       string
+		OwnerName                     string
+		DestinationAddressDescription string
+		Purpose                       string
+	}
+	mock.lockCreateAddressContext.RLock()
+	calls = mock.calls.CreateAddressContext
+	mock.lockCreateAddressContext.RUnlock()
+	return calls
+}
+
+// FindAddress calls FindAddressFunc.
+func (mock *CryptoServiceMock) FindAddress(address string) (*eversendSdk.CryptoAddress, error) {
+	if mock.FindAddressFunc == nil {
+		panic("CryptoServiceMock.FindAddressFunc: method is nil but CryptoService.FindAddress was just called")
+	}
+	callInfo := struct {
+		Address string
+	}{
+		Address: address,
+	}
+	mock.lockFindAddress.Lock()
+	mock.calls.FindAddress = append(mock.calls.FindAddress, callInfo)
+	mock.lockFindAddress.Unlock()
+	return mock.FindAddressFunc(address)
+}
+
+// FindAddressCalls gets all the calls that were made to FindAddress.
+// Check the length with:
+//
+//	len(mockedCryptoService.FindAddressCalls())
+func (mock *CryptoServiceMock) FindAddressCalls() []struct {
+	Address string
+} {
+	var calls []struct {
+		Address string
+	}
+	mock.lockFindAddress.RLock()
+	calls = mock.calls.FindAddress
+	mock.lockFindAddress.RUnlock()
+	return calls
+}
+
+// FindAddressContext calls FindAddressContextFunc.
+func (mock *CryptoServiceMock) FindAddressContext(ctx context.Context, address string) (*eversendSdk.CryptoAddress, error) {
+	if mock.FindAddressContextFunc == nil {
+		panic("CryptoServiceMock.FindAddressContextFunc: method is nil but CryptoService.FindAddressContext was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Address string
+	}{
+		Ctx:     ctx,
+		Address: address,
+	}
+	mock.lockFindAddressContext.Lock()
+	mock.calls.FindAddressContext = append(mock.calls.FindAddressContext, callInfo)
+	mock.lockFindAddressContext.Unlock()
+	return mock.FindAddressContextFunc(ctx, address)
+}
+
+// FindAddressContextCalls gets all the calls that were made to FindAddressContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.FindAddressContextCalls())
+func (mock *CryptoServiceMock) FindAddressContextCalls() []struct {
+	Ctx     context.Context
+	Address string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Address string
+	}
+	mock.lockFindAddressContext.RLock()
+	calls = mock.calls.FindAddressContext
+	mock.lockFindAddressContext.RUnlock()
+	return calls
+}
+
+// SearchAddresses calls SearchAddressesFunc.
+func (mock *CryptoServiceMock) SearchAddresses(query eversendSdk.AddressSearch) ([]eversendSdk.CryptoAddress, error) {
+	if mock.SearchAddressesFunc == nil {
+		panic("CryptoServiceMock.SearchAddressesFunc: method is nil but CryptoService.SearchAddresses was just called")
+	}
+	callInfo := struct {
+		Query eversendSdk.AddressSearch
+	}{
+		Query: query,
+	}
+	mock.lockSearchAddresses.Lock()
+	mock.calls.SearchAddresses = append(mock.calls.SearchAddresses, callInfo)
+	mock.lockSearchAddresses.Unlock()
+	return mock.SearchAddressesFunc(query)
+}
+
+// SearchAddressesCalls gets all the calls that were made to SearchAddresses.
+// Check the length with:
+//
+//	len(mockedCryptoService.SearchAddressesCalls())
+func (mock *CryptoServiceMock) SearchAddressesCalls() []struct {
+	Query eversendSdk.AddressSearch
+} {
+	var calls []struct {
+		Query eversendSdk.AddressSearch
+	}
+	mock.lockSearchAddresses.RLock()
+	calls = mock.calls.SearchAddresses
+	mock.lockSearchAddresses.RUnlock()
+	return calls
+}
+
+// SearchAddressesContext calls SearchAddressesContextFunc.
+func (mock *CryptoServiceMock) SearchAddressesContext(ctx context.Context, query eversendSdk.AddressSearch) ([]eversendSdk.CryptoAddress, error) {
+	if mock.SearchAddressesContextFunc == nil {
+		panic("CryptoServiceMock.SearchAddressesContextFunc: method is nil but CryptoService.SearchAddressesContext was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Query eversendSdk.AddressSearch
+	}{
+		Ctx:   ctx,
+		Query: query,
+	}
+	mock.lockSearchAddressesContext.Lock()
+	mock.calls.SearchAddressesContext = append(mock.calls.SearchAddressesContext, callInfo)
+	mock.lockSearchAddressesContext.Unlock()
+	return mock.SearchAddressesContextFunc(ctx, query)
+}
+
+// SearchAddressesContextCalls gets all the calls that were made to SearchAddressesContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.SearchAddressesContextCalls())
+func (mock *CryptoServiceMock) SearchAddressesContextCalls() []struct {
+	Ctx   context.Context
+	Query eversendSdk.AddressSearch
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Query eversendSdk.AddressSearch
+	}
+	mock.lockSearchAddressesContext.RLock()
+	calls = mock.calls.SearchAddressesContext
+	mock.lockSearchAddressesContext.RUnlock()
+	return calls
+}
+
+// Transactions calls TransactionsFunc.
+func (mock *CryptoServiceMock) Transactions() ([]eversendSdk.CryptoTransaction, error) {
+	if mock.TransactionsFunc == nil {
+		panic("CryptoServiceMock.TransactionsFunc: method is nil but CryptoService.Transactions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTransactions.Lock()
+	mock.calls.Transactions = append(mock.calls.Transactions, callInfo)
+	mock.lockTransactions.Unlock()
+	return mock.TransactionsFunc()
+}
+
+// TransactionsCalls gets all the calls that were made to Transactions.
+// Check the length with:
+//
+//	len(mockedCryptoService.TransactionsCalls())
+func (mock *CryptoServiceMock) TransactionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTransactions.RLock()
+	calls = mock.calls.Transactions
+	mock.lockTransactions.RUnlock()
+	return calls
+}
+
+// TransactionsContext calls TransactionsContextFunc.
+func (mock *CryptoServiceMock) TransactionsContext(ctx context.Context) ([]eversendSdk.CryptoTransaction, error) {
+	if mock.TransactionsContextFunc == nil {
+		panic("CryptoServiceMock.TransactionsContextFunc: method is nil but CryptoService.TransactionsContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTransactionsContext.Lock()
+	mock.calls.TransactionsContext = append(mock.calls.TransactionsContext, callInfo)
+	mock.lockTransactionsContext.Unlock()
+	return mock.TransactionsContextFunc(ctx)
+}
+
+// TransactionsContextCalls gets all the calls that were made to TransactionsContext.
+// Check the length with:
+//
+//	len(mockedCryptoService.TransactionsContextCalls())
+func (mock *CryptoServiceMock) TransactionsContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockTransactionsContext.RLock()
+	calls = mock.calls.TransactionsContext
+	mock.lockTransactionsContext.RUnlock()
+	return calls
+}
+
+// Ensure, that CollectionServiceMock does implement eversendSdk.CollectionService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.CollectionService = &CollectionServiceMock{}
+
+// CollectionServiceMock is a mock implementation of eversendSdk.CollectionService.
+//
+//	func TestSomethingThatUsesCollectionService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.CollectionService
+//		mockedCollectionService := &CollectionServiceMock{
+//			FeesFunc: func(request eversendSdk.CollectionFeesRequest) (*eversendSdk.CollectionFees, error) {
+//				panic("mock out the Fees method")
+//			},
+//			FeesContextFunc: func(ctx context.Context, request eversendSdk.CollectionFeesRequest) (*eversendSdk.CollectionFees, error) {
+//				panic("mock out the FeesContext method")
+//			},
+//			MomoFunc: func(request eversendSdk.MomoCollectionRequest) (*eversendSdk.CollectionTransaction, error) {
+//				panic("mock out the Momo method")
+//			},
+//			MomoContextFunc: func(ctx context.Context, request eversendSdk.MomoCollectionRequest) (*eversendSdk.CollectionTransaction, error) {
+//				panic("mock out the MomoContext method")
+//			},
+//			RequestOTPFunc: func(phone string) (*eversendSdk.CollectionOTP, error) {
+//				panic("mock out the RequestOTP method")
+//			},
+//			RequestOTPContextFunc: func(ctx context.Context, phone string) (*eversendSdk.CollectionOTP, error) {
+//				panic("mock out the RequestOTPContext method")
+//			},
+//			StatusFunc: func(transactionId string) (*eversendSdk.CollectionTransaction, error) {
+//				panic("mock out the Status method")
+//			},
+//			StatusContextFunc: func(ctx context.Context, transactionId string) (*eversendSdk.CollectionTransaction, error) {
+//				panic("mock out the StatusContext method")
+//			},
+//			VerifyOTPFunc: func(pinId string, pin string) error {
+//				panic("mock out the VerifyOTP method")
+//			},
+//			VerifyOTPContextFunc: func(ctx context.Context, pinId string, pin string) error {
+//				panic("mock out the VerifyOTPContext method")
+//			},
+//		}
+//
+//		// use mockedCollectionService in code that requires eversendSdk.CollectionService
+//		// and then make assertions.
+//
+//	}
+type CollectionServiceMock struct {
+	// FeesFunc mocks the Fees method.
+	FeesFunc func(request eversendSdk.CollectionFeesRequest) (*eversendSdk.CollectionFees, error)
+
+	// FeesContextFunc mocks the FeesContext method.
+	FeesContextFunc func(ctx context.Context, request eversendSdk.CollectionFeesRequest) (*eversendSdk.CollectionFees, error)
+
+	// MomoFunc mocks the Momo method.
+	MomoFunc func(request eversendSdk.MomoCollectionRequest) (*eversendSdk.CollectionTransaction, error)
+
+	// MomoContextFunc mocks the MomoContext method.
+	MomoContextFunc func(ctx context.Context, request eversendSdk.MomoCollectionRequest) (*eversendSdk.CollectionTransaction, error)
+
+	// RequestOTPFunc mocks the RequestOTP method.
+	RequestOTPFunc func(phone string) (*eversendSdk.CollectionOTP, error)
+
+	// RequestOTPContextFunc mocks the RequestOTPContext method.
+	RequestOTPContextFunc func(ctx context.Context, phone string) (*eversendSdk.CollectionOTP, error)
+
+	// StatusFunc mocks the Status method.
+	StatusFunc func(transactionId string) (*eversendSdk.CollectionTransaction, error)
+
+	// StatusContextFunc mocks the StatusContext method.
+	StatusContextFunc func(ctx context.Context, transactionId string) (*eversendSdk.CollectionTransaction, error)
+
+	// VerifyOTPFunc mocks the VerifyOTP method.
+	VerifyOTPFunc func(pinId string, pin string) error
+
+	// VerifyOTPContextFunc mocks the VerifyOTPContext method.
+	VerifyOTPContextFunc func(ctx context.Context, pinId string, pin string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Fees holds details about calls to the Fees method.
+		Fees []struct {
+			// Request is the request argument value.
+			Request eversendSdk.CollectionFeesRequest
+		}
+		// FeesContext holds details about calls to the FeesContext method.
+		FeesContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request eversendSdk.CollectionFeesRequest
+		}
+		// Momo holds details about calls to the Momo method.
+		Momo []struct {
+			// Request is the request argument value.
+			Request eversendSdk.MomoCollectionRequest
+		}
+		// MomoContext holds details about calls to the MomoContext method.
+		MomoContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request eversendSdk.MomoCollectionRequest
+		}
+		// RequestOTP holds details about calls to the RequestOTP method.
+		RequestOTP []struct {
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// RequestOTPContext holds details about calls to the RequestOTPContext method.
+		RequestOTPContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// Status holds details about calls to the Status method.
+		Status []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+		// StatusContext holds details about calls to the StatusContext method.
+		StatusContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+		// VerifyOTP holds details about calls to the VerifyOTP method.
+		VerifyOTP []struct {
+			// PinId is the pinId argument value.
+			PinId string
+			// Pin is the pin argument value.
+			Pin string
+		}
+		// VerifyOTPContext holds details about calls to the VerifyOTPContext method.
+		VerifyOTPContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PinId is the pinId argument value.
+			PinId string
+			// Pin is the pin argument value.
+			Pin string
+		}
+	}
+	lockFees              sync.RWMutex
+	lockFeesContext       sync.RWMutex
+	lockMomo              sync.RWMutex
+	lockMomoContext       sync.RWMutex
+	lockRequestOTP        sync.RWMutex
+	lockRequestOTPContext sync.RWMutex
+	lockStatus            sync.RWMutex
+	lockStatusContext     sync.RWMutex
+	lockVerifyOTP         sync.RWMutex
+	lockVerifyOTPContext  sync.RWMutex
+}
+
+// Fees calls FeesFunc.
+func (mock *CollectionServiceMock) Fees(request eversendSdk.CollectionFeesRequest) (*eversendSdk.CollectionFees, error) {
+	if mock.FeesFunc == nil {
+		panic("CollectionServiceMock.FeesFunc: method is nil but CollectionService.Fees was just called")
+	}
+	callInfo := struct {
+		Request eversendSdk.CollectionFeesRequest
+	}{
+		Request: request,
+	}
+	mock.lockFees.Lock()
+	mock.calls.Fees = append(mock.calls.Fees, callInfo)
+	mock.lockFees.Unlock()
+	return mock.FeesFunc(request)
+}
+
+// FeesCalls gets all the calls that were made to Fees.
+// Check the length with:
+//
+//	len(mockedCollectionService.FeesCalls())
+func (mock *CollectionServiceMock) FeesCalls() []struct {
+	Request eversendSdk.CollectionFeesRequest
+} {
+	var calls []struct {
+		Request eversendSdk.CollectionFeesRequest
+	}
+	mock.lockFees.RLock()
+	calls = mock.calls.Fees
+	mock.lockFees.RUnlock()
+	return calls
+}
+
+// FeesContext calls FeesContextFunc.
+func (mock *CollectionServiceMock) FeesContext(ctx context.Context, request eversendSdk.CollectionFeesRequest) (*eversendSdk.CollectionFees, error) {
+	if mock.FeesContextFunc == nil {
+		panic("CollectionServiceMock.FeesContextFunc: method is nil but CollectionService.FeesContext was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request eversendSdk.CollectionFeesRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockFeesContext.Lock()
+	mock.calls.FeesContext = append(mock.calls.FeesContext, callInfo)
+	mock.lockFeesContext.Unlock()
+	return mock.FeesContextFunc(ctx, request)
+}
+
+// FeesContextCalls gets all the calls that were made to FeesContext.
+// Check the length with:
+//
+//	len(mockedCollectionService.FeesContextCalls())
+func (mock *CollectionServiceMock) FeesContextCalls() []struct {
+	Ctx     context.Context
+	Request eversendSdk.CollectionFeesRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request eversendSdk.CollectionFeesRequest
+	}
+	mock.lockFeesContext.RLock()
+	calls = mock.calls.FeesContext
+	mock.lockFeesContext.RUnlock()
+	return calls
+}
+
+// Momo calls MomoFunc.
+func (mock *CollectionServiceMock) Momo(request eversendSdk.MomoCollectionRequest) (*eversendSdk.CollectionTransaction, error) {
+	if mock.MomoFunc == nil {
+		panic("CollectionServiceMock.MomoFunc: method is nil but CollectionService.Momo was just called")
+	}
+	callInfo := struct {
+		Request eversendSdk.MomoCollectionRequest
+	}{
+		Request: request,
+	}
+	mock.lockMomo.Lock()
+	mock.calls.Momo = append(mock.calls.Momo, callInfo)
+	mock.lockMomo.Unlock()
+	return mock.MomoFunc(request)
+}
+
+// MomoCalls gets all the calls that were made to Momo.
+// Check the length with:
+//
+//	len(mockedCollectionService.MomoCalls())
+func (mock *CollectionServiceMock) MomoCalls() []struct {
+	Request eversendSdk.MomoCollectionRequest
+} {
+	var calls []struct {
+		Request eversendSdk.MomoCollectionRequest
+	}
+	mock.lockMomo.RLock()
+	calls = mock.calls.Momo
+	mock.lockMomo.RUnlock()
+	return calls
+}
+
+// MomoContext calls MomoContextFunc.
+func (mock *CollectionServiceMock) MomoContext(ctx context.Context, request eversendSdk.MomoCollectionRequest) (*eversendSdk.CollectionTransaction, error) {
+	if mock.MomoContextFunc == nil {
+		panic("CollectionServiceMock.MomoContextFunc: method is nil but CollectionService.MomoContext was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request eversendSdk.MomoCollectionRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockMomoContext.Lock()
+	mock.calls.MomoContext = append(mock.calls.MomoContext, callInfo)
+	mock.lockMomoContext.Unlock()
+	return mock.MomoContextFunc(ctx, request)
+}
+
+// MomoContextCalls gets all the calls that were made to MomoContext.
+// Check the length with:
+//
+//	len(mockedCollectionService.MomoContextCalls())
+func (mock *CollectionServiceMock) MomoContextCalls() []struct {
+	Ctx     context.Context
+	Request eversendSdk.MomoCollectionRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request eversendSdk.MomoCollectionRequest
+	}
+	mock.lockMomoContext.RLock()
+	calls = mock.calls.MomoContext
+	mock.lockMomoContext.RUnlock()
+	return calls
+}
+
+// RequestOTP calls RequestOTPFunc.
+func (mock *CollectionServiceMock) RequestOTP(phone string) (*eversendSdk.CollectionOTP, error) {
+	if mock.RequestOTPFunc == nil {
+		panic("CollectionServiceMock.RequestOTPFunc: method is nil but CollectionService.RequestOTP was just called")
+	}
+	callInfo := struct {
+		Phone string
+	}{
+		Phone: phone,
+	}
+	mock.lockRequestOTP.Lock()
+	mock.calls.RequestOTP = append(mock.calls.RequestOTP, callInfo)
+	mock.lockRequestOTP.Unlock()
+	return mock.RequestOTPFunc(phone)
+}
+
+// RequestOTPCalls gets all the calls that were made to RequestOTP.
+// Check the length with:
+//
+//	len(mockedCollectionService.RequestOTPCalls())
+func (mock *CollectionServiceMock) RequestOTPCalls() []struct {
+	Phone string
+} {
+	var calls []struct {
+		Phone string
+	}
+	mock.lockRequestOTP.RLock()
+	calls = mock.calls.RequestOTP
+	mock.lockRequestOTP.RUnlock()
+	return calls
+}
+
+// RequestOTPContext calls RequestOTPContextFunc.
+func (mock *CollectionServiceMock) RequestOTPContext(ctx context.Context, phone string) (*eversendSdk.CollectionOTP, error) {
+	if mock.RequestOTPContextFunc == nil {
+		panic("CollectionServiceMock.RequestOTPContextFunc: method is nil but CollectionService.RequestOTPContext was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Phone string
+	}{
+		Ctx:   ctx,
+		Phone: phone,
+	}
+	mock.lockRequestOTPContext.Lock()
+	mock.calls.RequestOTPContext = append(mock.calls.RequestOTPContext, callInfo)
+	mock.lockRequestOTPContext.Unlock()
+	return mock.RequestOTPContextFunc(ctx, phone)
+}
+
+// RequestOTPContextCalls gets all the calls that were made to RequestOTPContext.
+// Check the length with:
+//
+//	len(mockedCollectionService.RequestOTPContextCalls())
+func (mock *CollectionServiceMock) RequestOTPContextCalls() []struct {
+	Ctx   context.Context
+	Phone string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Phone string
+	}
+	mock.lockRequestOTPContext.RLock()
+	calls = mock.calls.RequestOTPContext
+	mock.lockRequestOTPContext.RUnlock()
+	return calls
+}
+
+// Status calls StatusFunc.
+func (mock *CollectionServiceMock) Status(transactionId string) (*eversendSdk.CollectionTransaction, error) {
+	if mock.StatusFunc == nil {
+		panic("CollectionServiceMock.StatusFunc: method is nil but CollectionService.Status was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockStatus.Lock()
+	mock.calls.Status = append(mock.calls.Status, callInfo)
+	mock.lockStatus.Unlock()
+	return mock.StatusFunc(transactionId)
+}
+
+// StatusCalls gets all the calls that were made to Status.
+// Check the length with:
+//
+//	len(mockedCollectionService.StatusCalls())
+func (mock *CollectionServiceMock) StatusCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockStatus.RLock()
+	calls = mock.calls.Status
+	mock.lockStatus.RUnlock()
+	return calls
+}
+
+// StatusContext calls StatusContextFunc.
+func (mock *CollectionServiceMock) StatusContext(ctx context.Context, transactionId string) (*eversendSdk.CollectionTransaction, error) {
+	if mock.StatusContextFunc == nil {
+		panic("CollectionServiceMock.StatusContextFunc: method is nil but CollectionService.StatusContext was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		TransactionId string
+	}{
+		Ctx:           ctx,
+		TransactionId: transactionId,
+	}
+	mock.lockStatusContext.Lock()
+	mock.calls.StatusContext = append(mock.calls.StatusContext, callInfo)
+	mock.lockStatusContext.Unlock()
+	return mock.StatusContextFunc(ctx, transactionId)
+}
+
+// StatusContextCalls gets all the calls that were made to StatusContext.
+// Check the length with:
+//
+//	len(mockedCollectionService.StatusContextCalls())
+func (mock *CollectionServiceMock) StatusContextCalls() []struct {
+	Ctx           context.Context
+	TransactionId string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		TransactionId string
+	}
+	mock.lockStatusContext.RLock()
+	calls = mock.calls.StatusContext
+	mock.lockStatusContext.RUnlock()
+	return calls
+}
+
+// VerifyOTP calls VerifyOTPFunc.
+func (mock *CollectionServiceMock) VerifyOTP(pinId string, pin string) error {
+	if mock.VerifyOTPFunc == nil {
+		panic("CollectionServiceMock.VerifyOTPFunc: method is nil but CollectionService.VerifyOTP was just called")
+	}
+	callInfo := struct {
+		PinId string
+		Pin   string
+	}{
+		PinId: pinId,
+		Pin:   pin,
+	}
+	mock.lockVerifyOTP.Lock()
+	mock.calls.VerifyOTP = append(mock.calls.VerifyOTP, callInfo)
+	mock.lockVerifyOTP.Unlock()
+	return mock.VerifyOTPFunc(pinId, pin)
+}
+
+// VerifyOTPCalls gets all the calls that were made to VerifyOTP.
+// Check the length with:
+//
+//	len(mockedCollectionService.VerifyOTPCalls())
+func (mock *CollectionServiceMock) VerifyOTPCalls() []struct {
+	PinId string
+	Pin   string
+} {
+	var calls []struct {
+		PinId string
+		Pin   string
+	}
+	mock.lockVerifyOTP.RLock()
+	calls = mock.calls.VerifyOTP
+	mock.lockVerifyOTP.RUnlock()
+	return calls
+}
+
+// VerifyOTPContext calls VerifyOTPContextFunc.
+func (mock *CollectionServiceMock) VerifyOTPContext(ctx context.Context, pinId string, pin string) error {
+	if mock.VerifyOTPContextFunc == nil {
+		panic("CollectionServiceMock.VerifyOTPContextFunc: method is nil but CollectionService.VerifyOTPContext was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		PinId string
+		Pin   string
+	}{
+		Ctx:   ctx,
+		PinId: pinId,
+		Pin:   pin,
+	}
+	mock.lockVerifyOTPContext.Lock()
+	mock.calls.VerifyOTPContext = append(mock.calls.VerifyOTPContext, callInfo)
+	mock.lockVerifyOTPContext.Unlock()
+	return mock.VerifyOTPContextFunc(ctx, pinId, pin)
+}
+
+// VerifyOTPContextCalls gets all the calls that were made to VerifyOTPContext.
+// Check the length with:
+//
+//	len(mockedCollectionService.VerifyOTPContextCalls())
+func (mock *CollectionServiceMock) VerifyOTPContextCalls() []struct {
+	Ctx   context.Context
+	PinId string
+	Pin   string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		PinId string
+		Pin   string
+	}
+	mock.lockVerifyOTPContext.RLock()
+	calls = mock.calls.VerifyOTPContext
+	mock.lockVerifyOTPContext.RUnlock()
+	return calls
+}
+
+// Ensure, that TransactionServiceMock does implement eversendSdk.TransactionService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.TransactionService = &TransactionServiceMock{}
+
+// TransactionServiceMock is a mock implementation of eversendSdk.TransactionService.
+//
+//	func TestSomethingThatUsesTransactionService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.TransactionService
+//		mockedTransactionService := &TransactionServiceMock{
+//			ListFunc: func(query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error) {
+//				panic("mock out the List method")
+//			},
+//			ListContextFunc: func(ctx context.Context, query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error) {
+//				panic("mock out the ListContext method")
+//			},
+//		}
+//
+//		// use mockedTransactionService in code that requires eversendSdk.TransactionService
+//		// and then make assertions.
+//
+//	}
+type TransactionServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error)
+
+	// ListContextFunc mocks the ListContext method.
+	ListContextFunc func(ctx context.Context, query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Query is the query argument value.
+			Query eversendSdk.TransactionQuery
+		}
+		// ListContext holds details about calls to the ListContext method.
+		ListContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query eversendSdk.TransactionQuery
+		}
+	}
+	lockList        sync.RWMutex
+	lockListContext sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *TransactionServiceMock) List(query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error) {
+	if mock.ListFunc == nil {
+		panic("TransactionServiceMock.ListFunc: method is nil but TransactionService.List was just called")
+	}
+	callInfo := struct {
+		Query eversendSdk.TransactionQuery
+	}{
+		Query: query,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(query)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedTransactionService.ListCalls())
+func (mock *TransactionServiceMock) ListCalls() []struct {
+	Query eversendSdk.TransactionQuery
+} {
+	var calls []struct {
+		Query eversendSdk.TransactionQuery
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ListContext calls ListContextFunc.
+func (mock *TransactionServiceMock) ListContext(ctx context.Context, query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error) {
+	if mock.ListContextFunc == nil {
+		panic("TransactionServiceMock.ListContextFunc: method is nil but TransactionService.ListContext was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Query eversendSdk.TransactionQuery
+	}{
+		Ctx:   ctx,
+		Query: query,
+	}
+	mock.lockListContext.Lock()
+	mock.calls.ListContext = append(mock.calls.ListContext, callInfo)
+	mock.lockListContext.Unlock()
+	return mock.ListContextFunc(ctx, query)
+}
+
+// ListContextCalls gets all the calls that were made to ListContext.
+// Check the length with:
+//
+//	len(mockedTransactionService.ListContextCalls())
+func (mock *TransactionServiceMock) ListContextCalls() []struct {
+	Ctx   context.Context
+	Query eversendSdk.TransactionQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Query eversendSdk.TransactionQuery
+	}
+	mock.lockListContext.RLock()
+	calls = mock.calls.ListContext
+	mock.lockListContext.RUnlock()
+	return calls
+}
+
+// Ensure, that AccountServiceMock does implement eversendSdk.AccountService.
+// If this is not the case, regenerate this file with moq.
+var _ eversendSdk.AccountService = &AccountServiceMock{}
+
+// AccountServiceMock is a mock implementation of eversendSdk.AccountService.
+//
+//	func TestSomethingThatUsesAccountService(t *testing.T) {
+//
+//		// make and configure a mocked eversendSdk.AccountService
+//		mockedAccountService := &AccountServiceMock{
+//			AccountProfileFunc: func() (map[string]interface{}, error) {
+//				panic("mock out the AccountProfile method")
+//			},
+//			AccountProfileContextFunc: func(ctx context.Context) (map[string]interface{}, error) {
+//				panic("mock out the AccountProfileContext method")
+//			},
+//		}
+//
+//		// use mockedAccountService in code that requires eversendSdk.AccountService
+//		// and then make assertions.
+//
+//	}
+type AccountServiceMock struct {
+	// AccountProfileFunc mocks the AccountProfile method.
+	AccountProfileFunc func() (map[string]interface{}, error)
+
+	// AccountProfileContextFunc mocks the AccountProfileContext method.
+	AccountProfileContextFunc func(ctx context.Context) (map[string]interface{}, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AccountProfile holds details about calls to the AccountProfile method.
+		AccountProfile []struct {
+		}
+		// AccountProfileContext holds details about calls to the AccountProfileContext method.
+		AccountProfileContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockAccountProfile        sync.RWMutex
+	lockAccountProfileContext sync.RWMutex
+}
+
+// AccountProfile calls AccountProfileFunc.
+func (mock *AccountServiceMock) AccountProfile() (map[string]interface{}, error) {
+	if mock.AccountProfileFunc == nil {
+		panic("AccountServiceMock.AccountProfileFunc: method is nil but AccountService.AccountProfile was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockAccountProfile.Lock()
+	mock.calls.AccountProfile = append(mock.calls.AccountProfile, callInfo)
+	mock.lockAccountProfile.Unlock()
+	return mock.AccountProfileFunc()
+}
+
+// AccountProfileCalls gets all the calls that were made to AccountProfile.
+// Check the length with:
+//
+//	len(mockedAccountService.AccountProfileCalls())
+func (mock *AccountServiceMock) AccountProfileCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockAccountProfile.RLock()
+	calls = mock.calls.AccountProfile
+	mock.lockAccountProfile.RUnlock()
+	return calls
+}
+
+// AccountProfileContext calls AccountProfileContextFunc.
+func (mock *AccountServiceMock) AccountProfileContext(ctx context.Context) (map[string]interface{}, error) {
+	if mock.AccountProfileContextFunc == nil {
+		panic("AccountServiceMock.AccountProfileContextFunc: method is nil but AccountService.AccountProfileContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockAccountProfileContext.Lock()
+	mock.calls.AccountProfileContext = append(mock.calls.AccountProfileContext, callInfo)
+	mock.lockAccountProfileContext.Unlock()
+	return mock.AccountProfileContextFunc(ctx)
+}
+
+// AccountProfileContextCalls gets all the calls that were made to AccountProfileContext.
+// Check the length with:
+//
+//	len(mockedAccountService.AccountProfileContextCalls())
+func (mock *AccountServiceMock) AccountProfileContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockAccountProfileContext.RLock()
+	calls = mock.calls.AccountProfileContext
+	mock.lockAccountProfileContext.RUnlock()
+	return calls
+}
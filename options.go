@@ -0,0 +1,67 @@
+package eversendSdk
+
+import (
+	"net/http"
+	"strings"
+)
+
+const defaultBaseUrl = "https://api.eversend.co/v1/"
+
+type options struct {
+	baseUrl     string
+	httpClient  *http.Client
+	middlewares []Middleware
+	credentials CredentialsProvider
+	preflight   bool
+	duplicates  bool
+}
+
+// Option is an optional setting passed to NewEversendApp.
+type Option func(o *options)
+
+// WithBaseUrl sets the URL of the Eversend API e.g "https://api.eversend.co/v1/".
+// It is used to point the SDK at a sandbox or at a fake server in tests.
+func WithBaseUrl(url string) Option {
+	return func(o *options) {
+		if !strings.HasSuffix(url, "/") {
+			url += "/"
+		}
+
+		o.baseUrl = url
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests to the Eversend API.
+// It is used to set timeouts, proxies or a custom http.RoundTripper such as a cassette recorder.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithMiddleware adds middlewares that wrap every request sent to the Eversend API.
+// The first middleware is the outermost, it sees the request first and the response last.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middleware...)
+	}
+}
+
+// WithPreflightCheck makes MomoPayout, BankPayout and Exchange check the balance of the source wallet with Wallet.Find
+// before sending a quotation. An *InsufficientFundsError is returned, without sending the payout or exchange, when
+// the balance is lower than the total of the quotation. Only the quotations created by this app can be checked,
+// an error matching ErrPreflightUnchecked is returned for the others.
+func WithPreflightCheck() Option {
+	return func(o *options) {
+		o.preflight = true
+	}
+}
+
+// WithDuplicateBeneficiaryCheck makes CreateMomoBeneficiary and CreateBankBeneficiary look for a saved beneficiary with
+// the same account with Beneficiary.FindDuplicate before creating one. A *DuplicateBeneficiaryError is returned,
+// without creating the beneficiary, when one is found. Without it the same beneficiary can be created again.
+func WithDuplicateBeneficiaryCheck() Option {
+	return func(o *options) {
+		o.duplicates = true
+	}
+}
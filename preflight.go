@@ -0,0 +1,131 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInsufficientFunds is matched by the error returned by the pre-flight check when the source wallet cannot pay a quotation.
+var ErrInsufficientFunds = errors.New("eversend: insufficient funds")
+
+// ErrPreflightUnchecked is matched by the error returned by the pre-flight check when it cannot check a quotation,
+// e.g a quotation created by another process. The payout or exchange is not sent. Send it with an app created
+// without WithPreflightCheck to skip the check.
+var ErrPreflightUnchecked = errors.New("eversend: pre-flight check cannot check the quotation")
+
+// InsufficientFundsError is returned by MomoPayout, BankPayout and Exchange when WithPreflightCheck is set and
+// the balance of the source wallet is lower than the total of the quotation, amount and fees. The payout or
+// exchange is not sent.
+type InsufficientFundsError struct {
+	Currency  string
+	Required  float64
+	Available float64
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("%s: %s wallet has %s, %s is required", ErrInsufficientFunds.Error(), e.Currency,
+		strconv.FormatFloat(e.Available, 'f', -1, 64), strconv.FormatFloat(e.Required, 'f', -1, 64))
+}
+
+// Is reports whether target is ErrInsufficientFunds.
+func (e *InsufficientFundsError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+// quotationTTL is how long a quotation is remembered for the pre-flight check. Eversend expires tokens sooner.
+const quotationTTL = 30 * time.Minute
+
+// quotedTotal is what a quotation takes from its source wallet.
+type quotedTotal struct {
+	currency string
+	total    float64
+	quotedAt time.Time
+}
+
+// recordQuotation remembers the total of the quotation returned by the API, if the pre-flight check is set.
+// The currencyKey and amountKey name the source wallet and amount in the quotation, the fees are added if any.
+func (c *client) recordQuotation(data map[string]interface{}, currencyKey string, amountKey string) {
+	if !c.preflight {
+		return
+	}
+
+	token, _ := data["token"].(string)
+	quotation, _ := data["quotation"].(map[string]interface{})
+
+	if token == "" || quotation == nil {
+		return
+	}
+
+	currency, _ := quotation[currencyKey].(string)
+	total, ok := ParseAmount(quotation["totalAmount"])
+
+	if !ok {
+		amount, hasAmount := ParseAmount(quotation[amountKey])
+		fees, _ := ParseAmount(quotation["totalFees"])
+		total, ok = amount+fees, hasAmount
+	}
+
+	if currency == "" || !ok {
+		return
+	}
+
+	now := time.Now()
+
+	c.quotationsMutex.Lock()
+	defer c.quotationsMutex.Unlock()
+
+	for t, q := range c.quotations {
+		if now.Sub(q.quotedAt) > quotationTTL {
+			delete(c.quotations, t)
+		}
+	}
+
+	c.quotations[token] = quotedTotal{currency: strings.ToUpper(currency), total: total, quotedAt: now}
+}
+
+// forgetQuotation drops a quotation once its token was used.
+func (c *client) forgetQuotation(token string) {
+	c.quotationsMutex.Lock()
+	defer c.quotationsMutex.Unlock()
+
+	delete(c.quotations, token)
+}
+
+// checkFunds is the pre-flight check. It returns an *InsufficientFundsError if the source wallet of the quotation
+// cannot pay it, or the error of Wallet.Find. An error matching ErrPreflightUnchecked is returned when the
+// quotation was not created by this app or the wallet has no balance, as its funds cannot be checked.
+func (c *client) checkFunds(ctx context.Context, token string) error {
+	if !c.preflight {
+		return nil
+	}
+
+	c.quotationsMutex.Lock()
+	q, ok := c.quotations[token]
+	c.quotationsMutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: the quotation was not created by this app or has expired", ErrPreflightUnchecked)
+	}
+
+	wallet, err := (&Wallet{service{c}}).FindContext(ctx, q.currency)
+
+	if err != nil {
+		return err
+	}
+
+	available, ok := WalletBalance(wallet)
+
+	if !ok {
+		return fmt.Errorf("%w: the %s wallet has no balance", ErrPreflightUnchecked, q.currency)
+	}
+
+	if available < q.total {
+		return &InsufficientFundsError{Currency: q.currency, Required: q.total, Available: available}
+	}
+
+	return nil
+}
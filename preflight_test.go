@@ -0,0 +1,125 @@
+package eversendSdk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func newPreflightApp(t *testing.T) (*Eversend, *eversendtest.Server) {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	return NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithPreflightCheck()), srv
+}
+
+func TestPreflightCheckPayout(t *testing.T) {
+	app, srv := newPreflightApp(t)
+	srv.SetBalance("UGX", 50000)
+
+	quotation, err := app.Payouts.Quotation("UGX", 50000, "momo", "KE", "KES", "SOURCE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := quotation["token"].(string)
+
+	_, err = app.Payouts.MomoPayout(token, "+254700000000", "Jane", "Doe", "KE")
+
+	var insufficient *InsufficientFundsError
+
+	if !errors.Is(err, ErrInsufficientFunds) || !errors.As(err, &insufficient) {
+		t.Fatalf("got %v, want ErrInsufficientFunds", err)
+	}
+
+	// the fake charges 1% of fees
+	if insufficient.Currency != "UGX" || insufficient.Required != 50500 || insufficient.Available != 50000 {
+		t.Fatalf("got %+v", insufficient)
+	}
+
+	if n := srv.RequestCount(http.MethodPost, "payouts"); n != 0 {
+		t.Fatalf("payout sent %d times", n)
+	}
+
+	srv.SetBalance("UGX", 60000)
+
+	if _, err := app.Payouts.MomoPayout(token, "+254700000000", "Jane", "Doe", "KE"); err != nil {
+		t.Fatal(err)
+	}
+
+	if balance := srv.Balance("UGX"); balance != 9500 {
+		t.Fatalf("got balance %v, want 9500", balance)
+	}
+}
+
+func TestPreflightCheckExchange(t *testing.T) {
+	app, srv := newPreflightApp(t)
+
+	quotation, err := app.Exchange.Quotation("KES", 200000, "UGX")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Exchange.Exchange(quotation["token"].(string))
+
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("got %v, want ErrInsufficientFunds", err)
+	}
+
+	if n := srv.RequestCount(http.MethodPost, "exchanges"); n != 0 {
+		t.Fatalf("exchange sent %d times", n)
+	}
+}
+
+func TestPreflightCheckDisabled(t *testing.T) {
+	app, srv := newTestApp(t)
+	srv.SetBalance("UGX", 100)
+
+	quotation, err := app.Payouts.Quotation("UGX", 50000, "momo", "KE", "KES", "SOURCE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Payouts.MomoPayout(quotation["token"].(string), "+254700000000", "Jane", "Doe", "KE")
+
+	if err == nil || errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("got %v, want the error of the API", err)
+	}
+
+	if n := srv.RequestCount(http.MethodGet, "wallets/UGX"); n != 0 {
+		t.Fatalf("wallet fetched %d times", n)
+	}
+}
+
+func TestPreflightCheckUnknownQuotation(t *testing.T) {
+	app, srv := newPreflightApp(t)
+
+	// a quotation created by another app is not checked by this one
+	other := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithPreflightCheck())
+	quotation, err := other.Payouts.Quotation("UGX", 1000, "momo", "KE", "KES", "SOURCE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Payouts.MomoPayout(quotation["token"].(string), "+254700000000", "Jane", "Doe", "KE")
+
+	if !errors.Is(err, ErrPreflightUnchecked) {
+		t.Fatalf("got %v, want ErrPreflightUnchecked", err)
+	}
+
+	if n := srv.RequestCount(http.MethodPost, "payouts"); n != 0 {
+		t.Fatalf("payout sent %d times", n)
+	}
+
+	if _, err := other.Payouts.MomoPayout(quotation["token"].(string), "+254700000000", "Jane", "Doe", "KE"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,302 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned, wrapped, by a fail-fast RateLimiter when a request would have to wait.
+var ErrRateLimited = errors.New("eversend: rate limit exceeded")
+
+// EndpointGroup is a group of endpoints sharing a rate limit.
+type EndpointGroup string
+
+const (
+	// GroupAuth is the auth token request.
+	GroupAuth EndpointGroup = "auth"
+	// GroupRead is the GET requests e.g Wallet.List and Payout.Transaction.
+	GroupRead EndpointGroup = "read"
+	// GroupWrite is the requests that create, change or delete e.g Payout.MomoPayout.
+	GroupWrite EndpointGroup = "write"
+)
+
+// RequestGroup returns the EndpointGroup of a request made by the SDK.
+func RequestGroup(req *http.Request) EndpointGroup {
+	if Operation(req.Context()) == "auth.token" {
+		return GroupAuth
+	}
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return GroupRead
+	}
+
+	return GroupWrite
+}
+
+// RateLimit is the token bucket of an EndpointGroup.
+// Rate is the number of requests allowed per second and Burst the number that can be sent at once.
+// A zero Rate does not limit the group.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter limits the requests sent to the Eversend API with a token bucket per EndpointGroup.
+// When the API responds with a 429 the group is paused for the Retry-After of the response and the request is sent again.
+//
+//	limiter := eversendSdk.NewRateLimiter(eversendSdk.RateLimit{Rate: 10, Burst: 20},
+//		eversendSdk.WithGroupLimit(eversendSdk.GroupWrite, eversendSdk.RateLimit{Rate: 2, Burst: 5}))
+//	app := eversendSdk.NewEversendApp(clientId, clientSecret, eversendSdk.WithMiddleware(limiter.Middleware()))
+//
+// A request waits for a token until its context, the ctx passed to the ...Context methods, is done.
+// Use WithFailFast to return ErrRateLimited instead of waiting.
+type RateLimiter struct {
+	limit      RateLimit
+	limits     map[EndpointGroup]RateLimit
+	failFast   bool
+	maxRetries int
+	maxPause   time.Duration
+
+	mutex   sync.Mutex
+	buckets map[EndpointGroup]*bucket
+}
+
+// RateLimiterOption is an optional setting passed to NewRateLimiter.
+type RateLimiterOption func(l *RateLimiter)
+
+// WithGroupLimit sets the limit of an EndpointGroup, replacing the limit passed to NewRateLimiter.
+func WithGroupLimit(group EndpointGroup, limit RateLimit) RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.limits[group] = limit
+	}
+}
+
+// WithFailFast makes requests that would wait for a token fail with ErrRateLimited, and 429 responses be returned without a retry.
+func WithFailFast() RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.failFast = true
+	}
+}
+
+// WithMaxRetries sets how many times a request is sent again after a 429 response. The default is 1.
+func WithMaxRetries(retries int) RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.maxRetries = retries
+	}
+}
+
+// WithMaxRetryAfter caps how long a group is paused by the Retry-After of a 429 response. The default is 1 minute.
+func WithMaxRetryAfter(pause time.Duration) RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.maxPause = pause
+	}
+}
+
+// NewRateLimiter function to create a RateLimiter applying the limit to every EndpointGroup without its own limit.
+func NewRateLimiter(limit RateLimit, opts ...RateLimiterOption) *RateLimiter {
+	l := &RateLimiter{
+		limit:      limit,
+		limits:     map[EndpointGroup]RateLimit{},
+		maxRetries: 1,
+		maxPause:   time.Minute,
+		buckets:    map[EndpointGroup]*bucket{},
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Wait blocks until a request of the group may be sent or the context is done.
+// A fail-fast RateLimiter returns ErrRateLimited instead of blocking.
+func (l *RateLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	b := l.bucket(group)
+
+	for {
+		delay := b.take(time.Now())
+
+		if delay == 0 {
+			return nil
+		}
+
+		if l.failFast {
+			return fmt.Errorf("%w: %s requests", ErrRateLimited, group)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Pause stops the requests of the group until the time e.g the Retry-After of a 429 response.
+func (l *RateLimiter) Pause(group EndpointGroup, until time.Time) {
+	l.bucket(group).pause(until)
+}
+
+// Middleware returns the Middleware that applies the RateLimiter to the requests of the SDK.
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			group := RequestGroup(req)
+
+			for retry := 0; ; retry++ {
+				if err := l.Wait(req.Context(), group); err != nil {
+					return nil, err
+				}
+
+				resp, err := next(req)
+
+				if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+					return resp, err
+				}
+
+				l.Pause(group, time.Now().Add(l.retryAfter(resp.Header.Get("Retry-After"))))
+
+				if l.failFast || retry >= l.maxRetries || (req.Body != nil && req.GetBody == nil) {
+					return resp, nil
+				}
+
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+
+				req, err = retryRequest(req)
+
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+func (l *RateLimiter) bucket(group EndpointGroup) *bucket {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	b, ok := l.buckets[group]
+
+	if !ok {
+		limit, ok := l.limits[group]
+
+		if !ok {
+			limit = l.limit
+		}
+
+		b = newBucket(limit)
+		l.buckets[group] = b
+	}
+
+	return b
+}
+
+// retryAfter returns the pause for a Retry-After header, given in seconds or as an HTTP date.
+// Without a usable header the group is paused for one second.
+func (l *RateLimiter) retryAfter(header string) time.Duration {
+	pause := time.Second
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		pause = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		// a date in the past, e.g because of clock skew, allows the retry now
+		pause = max(time.Until(date), 0)
+	}
+
+	if pause > l.maxPause {
+		pause = l.maxPause
+	}
+
+	return pause
+}
+
+// retryRequest returns a copy of the request with a fresh body for the next attempt.
+func retryRequest(req *http.Request) (*http.Request, error) {
+	ctx := context.WithValue(req.Context(), attemptKey{}, Attempt(req.Context())+1)
+	retry := req.Clone(ctx)
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+
+		if err != nil {
+			return nil, err
+		}
+
+		retry.Body = body
+	}
+
+	return retry, nil
+}
+
+type bucket struct {
+	mutex  sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+	paused time.Time
+}
+
+func newBucket(limit RateLimit) *bucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &bucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// take takes a token and returns 0, or returns how long to wait before trying again.
+func (b *bucket) take(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if now.Before(b.paused) {
+		return b.paused.Sub(now)
+	}
+
+	if b.limit.Rate <= 0 {
+		return 0
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+	b.last = now
+
+	if b.tokens > float64(b.limit.Burst) {
+		b.tokens = float64(b.limit.Burst)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+// pause stops the bucket until the time and empties it, so requests resume at the rate of the limit.
+func (b *bucket) pause(until time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if until.After(b.paused) {
+		b.paused = until
+	}
+
+	b.tokens = 0
+	b.last = until
+}
@@ -0,0 +1,159 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func newLimitedApp(t *testing.T, limiter *RateLimiter, middleware ...Middleware) (*Eversend, *eversendtest.Server) {
+	t.Helper()
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	middleware = append(middleware, limiter.Middleware())
+
+	return NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(middleware...)), srv
+}
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{}, WithGroupLimit(GroupRead, RateLimit{Rate: 50, Burst: 1}))
+	app, _ := newLimitedApp(t, limiter)
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if _, err := app.Wallets.List(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the first request uses the burst and the other four wait 20ms each
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Fatalf("5 requests took %v, want them limited to 50 per second", elapsed)
+	}
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 0.01, Burst: 1}, WithFailFast())
+	app, srv := newLimitedApp(t, limiter)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Wallets.Find("UGX"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got error %v, want ErrRateLimited", err)
+	}
+
+	if _, err := app.Exchange.Quotation("UGX", 1000, "KES"); err != nil {
+		t.Fatalf("got error %v, want the write group to have its own bucket", err)
+	}
+
+	if count := srv.RequestCount(http.MethodGet, "wallets/UGX"); count != 0 {
+		t.Fatalf("got %d limited requests at the server, want 0", count)
+	}
+}
+
+func TestRateLimiterWaitsWithContext(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{}, WithGroupLimit(GroupRead, RateLimit{Rate: 0.01, Burst: 1}))
+	app, _ := newLimitedApp(t, limiter)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := app.Wallets.ListContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want the deadline of the context", err)
+	}
+}
+
+func TestRateLimiterRetriesAfter429(t *testing.T) {
+	attempts := []int{}
+
+	record := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if Operation(req.Context()) == "payouts.quote" {
+				attempts = append(attempts, Attempt(req.Context()))
+			}
+
+			return next(req)
+		}
+	}
+
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultRateLimited, Path: "payouts/quotation", Delay: time.Second, Times: 1})
+
+	limiter := NewRateLimiter(RateLimit{})
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(limiter.Middleware(), record))
+
+	start := time.Now()
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quotation["token"] == nil {
+		t.Fatalf("got quotation %v without a token", quotation)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %v, want the Retry-After of 1s", elapsed)
+	}
+
+	if len(attempts) != 2 || attempts[1] != 2 {
+		t.Fatalf("got attempts %v, want [1 2]", attempts)
+	}
+}
+
+func TestRateLimiterReturns429WhenRetriesRunOut(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultRateLimited, Path: "wallets"})
+
+	limiter := NewRateLimiter(RateLimit{}, WithMaxRetries(0))
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()), WithMiddleware(limiter.Middleware()))
+
+	if _, err := app.Wallets.List(); err == nil || err.Error() != "Too Many Requests" {
+		t.Fatalf("got error %v, want the 429 message", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{}, WithMaxRetryAfter(time.Minute))
+
+	if got := limiter.retryAfter("3"); got != 3*time.Second {
+		t.Errorf("got %v for seconds, want 3s", got)
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+
+	if got := limiter.retryAfter(date); got <= 8*time.Second || got > 10*time.Second {
+		t.Errorf("got %v for a date 10s away", got)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+
+	if got := limiter.retryAfter(past); got != 0 {
+		t.Errorf("got %v for a date in the past, want 0", got)
+	}
+
+	if got := limiter.retryAfter("3600"); got != time.Minute {
+		t.Errorf("got %v, want it capped at 1m", got)
+	}
+
+	if got := limiter.retryAfter(""); got != time.Second {
+		t.Errorf("got %v without a header, want 1s", got)
+	}
+}
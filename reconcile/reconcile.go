@@ -0,0 +1,331 @@
+// Package reconcile checks the transactions of your own ledger against the transactions of Eversend.
+//
+//	records, err := reconcile.ReadFile("payouts.csv")
+//	...
+//	reconciler := reconcile.New(app.Transactions)
+//	report, err := reconciler.Run(ctx, records, from, to)
+//	...
+//	report.WriteCSV(os.Stdout)
+//
+// The Eversend transactions of the period are fetched page by page with Transaction.List. Each record is matched
+// with a transaction by its Eversend transaction id first, then by its reference, and last by its amount and
+// currency. The report lists the matched records, the matched records whose amount, currency or status differ,
+// the records Eversend has no transaction for and the transactions missing from the ledger. The Eversend
+// transactions sharing a reference are also listed, as they may be a payout sent twice.
+package reconcile
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+)
+
+// The ways a record is matched with a transaction.
+const (
+	MatchedByID        = "id"
+	MatchedByReference = "reference"
+	MatchedByAmount    = "amount"
+)
+
+// Transaction is an Eversend transaction returned by Transaction.List.
+type Transaction struct {
+	ID        string    `json:"id"`
+	Reference string    `json:"reference,omitempty"`
+	Type      string    `json:"type"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Reconciler reconciles a ledger with the transactions of Eversend.
+type Reconciler struct {
+	transactions    eversendSdk.TransactionService
+	transactionType string
+	pageSize        int
+	tolerance       float64
+	statuses        map[string]string
+	now             func() time.Time
+}
+
+// Option is an optional setting passed to New.
+type Option func(r *Reconciler)
+
+// WithTransactionType sets the type of the Eversend transactions reconciled. The default is "payout",
+// "" reconciles all of them.
+func WithTransactionType(transactionType string) Option {
+	return func(r *Reconciler) {
+		r.transactionType = transactionType
+	}
+}
+
+// WithPageSize sets the number of transactions fetched per request. The default is 100.
+func WithPageSize(size int) Option {
+	return func(r *Reconciler) {
+		r.pageSize = size
+	}
+}
+
+// WithAmountTolerance sets the largest difference between two amounts that are still equal. The default is 0.005.
+func WithAmountTolerance(tolerance float64) Option {
+	return func(r *Reconciler) {
+		r.tolerance = tolerance
+	}
+}
+
+// WithStatusMapping maps the statuses of Eversend e.g "successful" to the statuses of your ledger e.g "paid"
+// before they are compared. Statuses are compared case-insensitively.
+func WithStatusMapping(statuses map[string]string) Option {
+	return func(r *Reconciler) {
+		for eversendStatus, status := range statuses {
+			r.statuses[strings.ToLower(eversendStatus)] = status
+		}
+	}
+}
+
+// New function to create a Reconciler fetching the transactions with the TransactionService e.g app.Transactions.
+func New(transactions eversendSdk.TransactionService, opts ...Option) *Reconciler {
+	r := &Reconciler{
+		transactions:    transactions,
+		transactionType: "payout",
+		pageSize:        100,
+		tolerance:       0.005,
+		statuses:        map[string]string{},
+		now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run reconciles the records of the ledger with the Eversend transactions created between the from and to days,
+// inclusive.
+func (r *Reconciler) Run(ctx context.Context, ledger Ledger, from time.Time, to time.Time) (*Report, error) {
+	records, err := ledger.Records(ctx, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := r.Transactions(ctx, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := r.Reconcile(records, transactions)
+	report.From, report.To = from, to
+
+	return report, nil
+}
+
+// Transactions fetches every Eversend transaction of the type created between the from and to days.
+func (r *Reconciler) Transactions(ctx context.Context, from time.Time, to time.Time) ([]Transaction, error) {
+	transactions := []Transaction{}
+
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := r.transactions.ListContext(ctx, eversendSdk.TransactionQuery{
+			From:  from,
+			To:    to,
+			Type:  r.transactionType,
+			Page:  page,
+			Limit: r.pageSize,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range result.Transactions {
+			transactions = append(transactions, parseTransaction(data))
+		}
+
+		// the limit of the response is the one applied, the API may lower the one asked for
+		limit := result.Limit
+
+		if limit <= 0 {
+			limit = r.pageSize
+		}
+
+		// a response may have no total, so only a page that is not full is known to be the last one
+		last := len(result.Transactions) < limit || (result.Total > 0 && len(transactions) >= result.Total)
+
+		if len(result.Transactions) == 0 || last {
+			return transactions, nil
+		}
+	}
+}
+
+// Reconcile matches the records with the transactions, see the package documentation.
+func (r *Reconciler) Reconcile(records []Record, transactions []Transaction) *Report {
+	report := &Report{
+		GeneratedAt:         r.now(),
+		Matched:             []Match{},
+		Mismatched:          []Match{},
+		MissingInEversend:   []Record{},
+		MissingInLedger:     []Transaction{},
+		DuplicateReferences: []Transaction{},
+	}
+
+	matched := make([]bool, len(transactions))
+	byID := map[string][]int{}
+	byReference := map[string][]int{}
+
+	for i, transaction := range transactions {
+		if transaction.ID != "" {
+			byID[transaction.ID] = append(byID[transaction.ID], i)
+		}
+
+		if transaction.Reference != "" {
+			byReference[transaction.Reference] = append(byReference[transaction.Reference], i)
+		}
+	}
+
+	for i, transaction := range transactions {
+		if len(byReference[transaction.Reference]) > 1 {
+			report.DuplicateReferences = append(report.DuplicateReferences, transactions[i])
+		}
+	}
+
+	pending := []Record{}
+
+	for _, record := range records {
+		i, matchedBy := -1, ""
+
+		if j := firstUnmatched(byID[record.ID], matched); j >= 0 && record.ID != "" {
+			i, matchedBy = j, MatchedByID
+		} else if j := firstUnmatched(byReference[record.Reference], matched); j >= 0 && record.Reference != "" {
+			i, matchedBy = j, MatchedByReference
+		}
+
+		if i < 0 {
+			pending = append(pending, record)
+			continue
+		}
+
+		matched[i] = true
+		report.add(r.match(record, transactions[i], matchedBy))
+	}
+
+	// the records left are matched on the amount and currency, with the oldest transaction left
+	order := make([]int, len(transactions))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return transactions[order[a]].CreatedAt.Before(transactions[order[b]].CreatedAt)
+	})
+
+	for _, record := range pending {
+		found := -1
+
+		// a record with an id or reference Eversend does not know is missing, not matched on its amount
+		if record.ID == "" && record.Reference == "" {
+			for _, i := range order {
+				if !matched[i] && r.sameAmount(record, transactions[i]) {
+					found = i
+					break
+				}
+			}
+		}
+
+		if found < 0 {
+			report.MissingInEversend = append(report.MissingInEversend, record)
+			continue
+		}
+
+		matched[found] = true
+		report.add(r.match(record, transactions[found], MatchedByAmount))
+	}
+
+	for _, i := range order {
+		if !matched[i] {
+			report.MissingInLedger = append(report.MissingInLedger, transactions[i])
+		}
+	}
+
+	report.summarise()
+
+	return report
+}
+
+// firstUnmatched returns the first of the transactions not matched yet, or -1.
+func firstUnmatched(indexes []int, matched []bool) int {
+	for _, i := range indexes {
+		if !matched[i] {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (r *Reconciler) match(record Record, transaction Transaction, matchedBy string) Match {
+	m := Match{Record: record, Transaction: transaction, MatchedBy: matchedBy, Differences: []string{}}
+
+	if !strings.EqualFold(record.Currency, transaction.Currency) {
+		m.Differences = append(m.Differences, DifferenceCurrency)
+	}
+
+	if math.Abs(record.Amount-transaction.Amount) > r.tolerance {
+		m.Differences = append(m.Differences, DifferenceAmount)
+	}
+
+	if record.Status != "" && !strings.EqualFold(record.Status, r.status(transaction.Status)) {
+		m.Differences = append(m.Differences, DifferenceStatus)
+	}
+
+	return m
+}
+
+func (r *Reconciler) sameAmount(record Record, transaction Transaction) bool {
+	return strings.EqualFold(record.Currency, transaction.Currency) && math.Abs(record.Amount-transaction.Amount) <= r.tolerance
+}
+
+func (r *Reconciler) status(eversendStatus string) string {
+	if status, ok := r.statuses[strings.ToLower(eversendStatus)]; ok {
+		return status
+	}
+
+	return eversendStatus
+}
+
+// parseTransaction reads a transaction returned by Transaction.List. The amounts may be sent as strings.
+func parseTransaction(data map[string]interface{}) Transaction {
+	transaction := Transaction{
+		ID:        firstString(data, "transactionId", "id"),
+		Reference: firstString(data, "transactionRef", "reference"),
+		Type:      firstString(data, "type"),
+		Currency:  firstString(data, "currency"),
+		Status:    firstString(data, "status"),
+	}
+
+	transaction.Amount, _ = eversendSdk.ParseAmount(data["amount"])
+
+	transaction.CreatedAt, _ = time.Parse(time.RFC3339, firstString(data, "createdAt"))
+
+	return transaction
+}
+
+func firstString(data map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := data[key].(string); ok && value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,228 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	eversendSdk "github.com/cetric32/eversend_go_sdk"
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+	"github.com/cetric32/eversend_go_sdk/mocks"
+)
+
+func payout(t *testing.T, app *eversendSdk.Eversend, amount float64) string {
+	t.Helper()
+
+	quotation, err := app.Payouts.Quotation("UGX", amount, "momo", "KE", "KES", "SOURCE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction, err := app.Payouts.MomoPayout(quotation["token"].(string), "+254700000000", "Jane", "Doe", "KE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction["transactionId"].(string)
+}
+
+func TestReconcile(t *testing.T) {
+	srv := eversendtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	app := eversendSdk.NewEversendApp(srv.ClientID, srv.ClientSecret, eversendSdk.WithBaseUrl(srv.BaseURL()))
+
+	matched := payout(t, app, 1000)
+	wrongAmount := payout(t, app, 2000)
+	failed := payout(t, app, 3000)
+	byAmount := payout(t, app, 4000)
+	unknown := payout(t, app, 5000)
+
+	srv.SetTransactionStatus(failed, "failed")
+
+	// an exchange is not a payout and is not reconciled
+	quotation, err := app.Exchange.Quotation("UGX", 1000, "KES")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Exchange.Exchange(quotation["token"].(string)); err != nil {
+		t.Fatal(err)
+	}
+
+	records := Records{
+		{ID: matched, Amount: 1000, Currency: "UGX", Status: "paid"},
+		{ID: wrongAmount, Amount: 2500, Currency: "UGX"},
+		{ID: failed, Amount: 3000, Currency: "UGX", Status: "paid"},
+		{Amount: 4000, Currency: "ugx"},
+		{Reference: "INV-9", Amount: 6000, Currency: "UGX"},
+		// outside the period
+		{ID: "old", Amount: 1, Currency: "UGX", CreatedAt: time.Now().AddDate(0, -1, 0)},
+	}
+
+	today := time.Now()
+	reconciler := New(app.Transactions, WithPageSize(2), WithStatusMapping(map[string]string{"completed": "paid"}))
+	report, err := reconciler.Run(context.Background(), records, today, today)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Summary{Records: 5, Transactions: 5, Matched: 2, Mismatched: 2, MissingInEversend: 1, MissingInLedger: 1}
+
+	if report.Summary != want {
+		t.Fatalf("got %+v, want %+v", report.Summary, want)
+	}
+
+	if report.Reconciled() {
+		t.Fatal("report is reconciled")
+	}
+
+	if m := report.Matched[1]; m.Transaction.ID != byAmount || m.MatchedBy != MatchedByAmount {
+		t.Fatalf("got %+v, want %s matched by amount", m, byAmount)
+	}
+
+	for i, want := range []struct {
+		id         string
+		difference string
+	}{{wrongAmount, DifferenceAmount}, {failed, DifferenceStatus}} {
+		m := report.Mismatched[i]
+
+		if m.Transaction.ID != want.id || m.MatchedBy != MatchedByID || len(m.Differences) != 1 || m.Differences[0] != want.difference {
+			t.Fatalf("got %+v, want %s with a different %s", m, want.id, want.difference)
+		}
+	}
+
+	if report.MissingInEversend[0].Reference != "INV-9" || report.MissingInLedger[0].ID != unknown {
+		t.Fatalf("got missing %+v and %+v", report.MissingInEversend, report.MissingInLedger)
+	}
+
+	var out bytes.Buffer
+
+	if err := report.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := csv.NewReader(&out).ReadAll()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != 7 || lines[1][0] != ResultMismatched || lines[1][2] != DifferenceAmount || lines[1][5] != "2500" ||
+		lines[1][10] != "2000" || lines[3][0] != ResultMissingInEversend || lines[4][0] != ResultMissingInLedger {
+		t.Fatalf("got CSV %q", lines)
+	}
+}
+
+func TestReconcileDuplicateReferences(t *testing.T) {
+	now := time.Now()
+	transactions := []Transaction{
+		{ID: "BE1", Reference: "INV-1", Amount: 1000, Currency: "UGX", CreatedAt: now.Add(-time.Hour)},
+		{ID: "BE2", Reference: "INV-1", Amount: 1000, Currency: "UGX", CreatedAt: now},
+		{ID: "BE3", Reference: "INV-2", Amount: 2000, Currency: "UGX", CreatedAt: now},
+	}
+
+	// the ledger has a single INV-1, so one of the two is missing, the first is not lost
+	report := New(nil).Reconcile(Records{
+		{Reference: "INV-1", Amount: 1000, Currency: "UGX"},
+		{Reference: "INV-2", Amount: 2000, Currency: "UGX"},
+	}, transactions)
+
+	if len(report.Matched) != 2 || report.Matched[0].Transaction.ID != "BE1" {
+		t.Fatalf("got matches %+v, want INV-1 matched with BE1", report.Matched)
+	}
+
+	if len(report.MissingInLedger) != 1 || report.MissingInLedger[0].ID != "BE2" {
+		t.Fatalf("got missing %+v, want BE2", report.MissingInLedger)
+	}
+
+	if report.Summary.DuplicateReferences != 2 || report.DuplicateReferences[0].ID != "BE1" || report.DuplicateReferences[1].ID != "BE2" {
+		t.Fatalf("got duplicates %+v, want BE1 and BE2", report.DuplicateReferences)
+	}
+
+	var out bytes.Buffer
+
+	if err := report.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Count(out.String(), ResultDuplicateReference); lines != 2 {
+		t.Fatalf("got %d duplicate lines in the CSV:\n%s", lines, out.String())
+	}
+
+	// both are in the ledger, yet they are still reported
+	report = New(nil).Reconcile(Records{
+		{Reference: "INV-1", Amount: 1000, Currency: "UGX"},
+		{Reference: "INV-1", Amount: 1000, Currency: "UGX"},
+		{Reference: "INV-2", Amount: 2000, Currency: "UGX"},
+	}, transactions)
+
+	if len(report.Matched) != 3 || len(report.MissingInLedger) != 0 || len(report.DuplicateReferences) != 2 || report.Reconciled() {
+		t.Fatalf("got %+v, want every transaction matched and the duplicates reported", report.Summary)
+	}
+}
+
+func TestTransactionsWithoutTotal(t *testing.T) {
+	pages := 0
+
+	mock := &mocks.TransactionServiceMock{
+		ListContextFunc: func(ctx context.Context, query eversendSdk.TransactionQuery) (*eversendSdk.TransactionPage, error) {
+			pages++
+			page := &eversendSdk.TransactionPage{Transactions: []map[string]interface{}{}, Page: query.Page}
+
+			// 5 transactions, without a total or a limit in the response
+			for i := (query.Page - 1) * query.Limit; i < query.Page*query.Limit && i < 5; i++ {
+				page.Transactions = append(page.Transactions, map[string]interface{}{"transactionId": fmt.Sprintf("BE%d", i)})
+			}
+
+			return page, nil
+		},
+	}
+
+	transactions, err := New(mock, WithPageSize(2)).Transactions(context.Background(), time.Now(), time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transactions) != 5 || transactions[4].ID != "BE4" || pages != 3 {
+		t.Fatalf("got %d transactions in %d pages, want 5 in 3", len(transactions), pages)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	records, err := ReadCSV(strings.NewReader("Reference, Amount,currency,createdAt,notes\n" +
+		"INV-1,1000,UGX,2024-01-31,first\n" +
+		"INV-2,25.5,KES,2024-02-01T10:00:00Z,\n"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 || records[0].Reference != "INV-1" || records[1].Amount != 25.5 || records[1].Line != 3 ||
+		!records[0].CreatedAt.Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got %+v", records)
+	}
+
+	inPeriod, err := records.Records(context.Background(), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+
+	if err != nil || len(inPeriod) != 1 || inPeriod[0].Reference != "INV-2" {
+		t.Fatalf("got %+v, %v", inPeriod, err)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("reference,amount\nINV-1,1000\n")); err == nil {
+		t.Fatal("read records without a currency")
+	}
+
+	if _, err := ReadCSV(strings.NewReader("amount,currency\nabc,UGX\n")); err == nil {
+		t.Fatal("read an amount that is not a number")
+	}
+}
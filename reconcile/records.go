@@ -0,0 +1,166 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a transaction of your own ledger, e.g a payout saved in your database.
+// The ID is the Eversend transaction id when you stored it. The Reference is your own reference of the transaction,
+// matched against the transactionRef Eversend returns. A record needs one of them, or is matched on its amount
+// and currency only.
+type Record struct {
+	Line      int       `json:"line,omitempty"`
+	ID        string    `json:"id,omitempty"`
+	Reference string    `json:"reference,omitempty"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+// Ledger gives the records of your ledger for a period.
+type Ledger interface {
+	// Records returns the records created between the from and to days, inclusive.
+	Records(ctx context.Context, from time.Time, to time.Time) ([]Record, error)
+}
+
+// Records is a Ledger of records held in memory, e.g read with ReadCSV.
+type Records []Record
+
+// Records implements Ledger. Records without a CreatedAt are always returned.
+func (r Records) Records(ctx context.Context, from time.Time, to time.Time) ([]Record, error) {
+	records := []Record{}
+
+	for _, record := range r {
+		if record.CreatedAt.IsZero() || inPeriod(record.CreatedAt, from, to) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// inPeriod reports whether the day of t, in UTC, is between the days of from and to.
+func inPeriod(t time.Time, from time.Time, to time.Time) bool {
+	day := t.UTC().Format("2006-01-02")
+
+	return (from.IsZero() || day >= from.UTC().Format("2006-01-02")) && (to.IsZero() || day <= to.UTC().Format("2006-01-02"))
+}
+
+// csvColumns maps the lower-case CSV header of each text column to its Record field.
+var csvColumns = map[string]func(r *Record) *string{
+	"id":        func(r *Record) *string { return &r.ID },
+	"reference": func(r *Record) *string { return &r.Reference },
+	"currency":  func(r *Record) *string { return &r.Currency },
+	"status":    func(r *Record) *string { return &r.Status },
+}
+
+// ReadFile reads the records of a CSV file, see ReadCSV.
+func ReadFile(path string) (Records, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ReadCSV(f)
+}
+
+// ReadCSV reads records from CSV with a header line naming the columns e.g "id,reference,amount,currency,status,createdAt".
+// The columns are the JSON names of the Record fields, matched case-insensitively, in any order. Other columns are ignored,
+// so an export of your database can be read as is. The amount and currency columns are required.
+// The createdAt is a RFC 3339 time e.g "2024-01-31T10:00:00Z" or a date e.g "2024-01-31".
+func ReadCSV(r io.Reader) (Records, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+
+	if err == io.EOF {
+		return Records{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if !contains(header, "amount") || !contains(header, "currency") {
+		return nil, errors.New("reconcile: the amount and currency columns are required")
+	}
+
+	records := Records{}
+
+	for {
+		values, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line}
+
+		for i, value := range values {
+			value = strings.TrimSpace(value)
+
+			switch header[i] {
+			case "amount":
+				if record.Amount, err = strconv.ParseFloat(value, 64); err != nil {
+					return nil, fmt.Errorf("reconcile: line %d: amount %q is not a number", line, value)
+				}
+			case "createdat":
+				if record.CreatedAt, err = parseTime(value); err != nil {
+					return nil, fmt.Errorf("reconcile: line %d: createdAt %q is not a date", line, value)
+				}
+			default:
+				if field, ok := csvColumns[header[i]]; ok {
+					*field(&record) = value
+				}
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02", value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+package reconcile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The fields of a Match that may differ between the record and the transaction.
+const (
+	DifferenceAmount   = "amount"
+	DifferenceCurrency = "currency"
+	DifferenceStatus   = "status"
+)
+
+// The outcomes of a record or transaction, in the result column of the CSV report.
+const (
+	ResultMatched            = "matched"
+	ResultMismatched         = "mismatched"
+	ResultMissingInEversend  = "missing_in_eversend"
+	ResultMissingInLedger    = "missing_in_ledger"
+	ResultDuplicateReference = "duplicate_reference"
+)
+
+// Match is a record matched with an Eversend transaction. Differences lists the fields that differ, if any.
+type Match struct {
+	Record      Record      `json:"record"`
+	Transaction Transaction `json:"transaction"`
+	MatchedBy   string      `json:"matchedBy"`
+	Differences []string    `json:"differences"`
+}
+
+// Summary counts the entries of each list of a Report.
+type Summary struct {
+	Records             int `json:"records"`
+	Transactions        int `json:"transactions"`
+	Matched             int `json:"matched"`
+	Mismatched          int `json:"mismatched"`
+	MissingInEversend   int `json:"missingInEversend"`
+	MissingInLedger     int `json:"missingInLedger"`
+	DuplicateReferences int `json:"duplicateReferences"`
+}
+
+// Report is the outcome of a reconciliation. Mismatched holds the matches with differences, Matched the others.
+// MissingInEversend holds the records without a transaction, MissingInLedger the transactions without a record.
+// DuplicateReferences holds the transactions whose reference is shared by another transaction, whether they were
+// matched or not. Records with that reference are matched with them in the order of the transactions.
+type Report struct {
+	From                time.Time     `json:"from"`
+	To                  time.Time     `json:"to"`
+	GeneratedAt         time.Time     `json:"generatedAt"`
+	Summary             Summary       `json:"summary"`
+	Matched             []Match       `json:"matched"`
+	Mismatched          []Match       `json:"mismatched"`
+	MissingInEversend   []Record      `json:"missingInEversend"`
+	MissingInLedger     []Transaction `json:"missingInLedger"`
+	DuplicateReferences []Transaction `json:"duplicateReferences"`
+}
+
+func (r *Report) add(m Match) {
+	if len(m.Differences) > 0 {
+		r.Mismatched = append(r.Mismatched, m)
+	} else {
+		r.Matched = append(r.Matched, m)
+	}
+}
+
+func (r *Report) summarise() {
+	r.Summary = Summary{
+		Matched:             len(r.Matched),
+		Mismatched:          len(r.Mismatched),
+		MissingInEversend:   len(r.MissingInEversend),
+		MissingInLedger:     len(r.MissingInLedger),
+		DuplicateReferences: len(r.DuplicateReferences),
+	}
+
+	r.Summary.Records = r.Summary.Matched + r.Summary.Mismatched + r.Summary.MissingInEversend
+	r.Summary.Transactions = r.Summary.Matched + r.Summary.Mismatched + r.Summary.MissingInLedger
+}
+
+// Reconciled reports whether every record matched a transaction without differences, every transaction a record
+// and no two transactions share a reference.
+func (r *Report) Reconciled() bool {
+	return len(r.Mismatched) == 0 && len(r.MissingInEversend) == 0 && len(r.MissingInLedger) == 0 &&
+		len(r.DuplicateReferences) == 0
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+var csvHeader = []string{
+	"result", "matchedBy", "differences",
+	"id", "reference", "amount", "currency", "status",
+	"eversendId", "eversendReference", "eversendAmount", "eversendCurrency", "eversendStatus", "eversendCreatedAt",
+}
+
+// WriteCSV writes one line per record or transaction: the mismatches first, then the records missing in Eversend,
+// the transactions missing in the ledger and the matches. The differences are separated by ";".
+// The transactions sharing a reference follow, on a line of their own.
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(csvHeader)
+
+	for _, m := range r.Mismatched {
+		writer.Write(csvLine(ResultMismatched, m.MatchedBy, m.Differences, &m.Record, &m.Transaction))
+	}
+
+	for i := range r.MissingInEversend {
+		writer.Write(csvLine(ResultMissingInEversend, "", nil, &r.MissingInEversend[i], nil))
+	}
+
+	for i := range r.MissingInLedger {
+		writer.Write(csvLine(ResultMissingInLedger, "", nil, nil, &r.MissingInLedger[i]))
+	}
+
+	for _, m := range r.Matched {
+		writer.Write(csvLine(ResultMatched, m.MatchedBy, nil, &m.Record, &m.Transaction))
+	}
+
+	for i := range r.DuplicateReferences {
+		writer.Write(csvLine(ResultDuplicateReference, "", nil, nil, &r.DuplicateReferences[i]))
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func csvLine(result string, matchedBy string, differences []string, record *Record, transaction *Transaction) []string {
+	line := make([]string, 0, len(csvHeader))
+	line = append(line, result, matchedBy, strings.Join(differences, ";"))
+
+	if record != nil {
+		line = append(line, record.ID, record.Reference, formatAmount(record.Amount), record.Currency, record.Status)
+	} else {
+		line = append(line, "", "", "", "", "")
+	}
+
+	if transaction != nil {
+		createdAt := ""
+
+		if !transaction.CreatedAt.IsZero() {
+			createdAt = transaction.CreatedAt.Format(time.RFC3339)
+		}
+
+		line = append(line, transaction.ID, transaction.Reference, formatAmount(transaction.Amount), transaction.Currency,
+			transaction.Status, createdAt)
+	} else {
+		line = append(line, "", "", "", "", "", "")
+	}
+
+	return line
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -1,190 +1,252 @@
 package eversendSdk
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"sync"
+	"net/http"
 	"time"
-
-	"github.com/cetric32/GoHTTP"
 )
 
-var eversendClientId string
-var eversendClientSecret string
-var baseUrl string = "https://api.eversend.co/v1/"
-var authToken string
-var mutex = &sync.RWMutex{}
-var authTokenExpires time.Time
-
 // Eversend struct
+// The services are interfaces, implemented by the Wallet, Payout etc structs, so that they can be replaced with
+// the mocks of the mocks package in tests. Code that used the fields as e.g *Wallet must use WalletService instead.
 type Eversend struct {
 	// clientId     string
 	// clientSecret string
 	// baseUrl      string
 	// authToken    string
 
-	Crypto        Crypto
-	Wallets       Wallet
-	Exchange      Exchange
-	Payouts       Payout
-	Beneficiaries Beneficiary
+	Crypto        CryptoService
+	Wallets       WalletService
+	Exchange      ExchangeService
+	Payouts       PayoutService
+	Beneficiaries BeneficiaryService
+	Collections   CollectionService
+	Transactions  TransactionService
+
+	service
 }
 
-type Crypto struct{}
-type Wallet struct{}
-type Exchange struct{}
-type Payout struct{}
-type Beneficiary struct{}
+type Crypto struct{ service }
+type Wallet struct{ service }
+type Exchange struct{ service }
+type Payout struct{ service }
+type Beneficiary struct{ service }
+type Collection struct{ service }
+type Transaction struct{ service }
 
 // NewEversend function to create a new Eversend instance
-func NewEversendApp(clientId string, clientSecret string) *Eversend {
-	mutex.Lock()
-	defer mutex.Unlock()
+// The opts are optional settings e.g WithBaseUrl.
+// The clientId and clientSecret are ignored when a provider is set with WithCredentialsProvider.
+func NewEversendApp(clientId string, clientSecret string, opts ...Option) *Eversend {
+	o := options{
+		baseUrl:    defaultBaseUrl,
+		httpClient: &http.Client{},
+	}
 
-	eversendClientId = clientId
-	eversendClientSecret = clientSecret
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.credentials == nil {
+		o.credentials = NewStaticCredentials(clientId, clientSecret)
+	}
 
-	return &Eversend{}
+	c := newClient(o)
+
+	defaultClientMutex.Lock()
+	defaultClient = c
+	defaultClientMutex.Unlock()
+
+	return &Eversend{
+		Crypto:        &Crypto{service{c}},
+		Wallets:       &Wallet{service{c}},
+		Exchange:      &Exchange{service{c}},
+		Payouts:       &Payout{service{c}},
+		Beneficiaries: &Beneficiary{service{c}},
+		Collections:   &Collection{service{c}},
+		Transactions:  &Transaction{service{c}},
+		service:       service{c},
+	}
 }
 
-func generateAuthToken() (string, error) {
+// generateAuthToken returns the cached auth token, or gets a new one if it expired.
+// Concurrent calls share one token request, which is not cancelled when the ctx of the call that started it is done.
+func (c *client) generateAuthToken(ctx context.Context) (string, error) {
 	//current time now UTC
 	currentTime := time.Now()
 
-	mutex.RLock()
-	clientId := eversendClientId
-	clientSecret := eversendClientSecret
-	currentToken := authToken
-	currentTokenExpires := authTokenExpires
-	mutex.RUnlock()
+	c.mutex.Lock()
 
-	if currentToken != "" && currentTokenExpires.After(currentTime) {
-		fmt.Println("existing token", currentToken, "expires", currentTokenExpires, "currentTime", currentTime)
-		return currentToken, nil
+	if c.authToken != "" && c.authTokenExpires.After(currentTime) {
+		defer c.mutex.Unlock()
+		return c.authToken, nil
 	}
 
-	url := baseUrl + "auth/token"
+	refresh := c.refresh
 
-	goHttp := GoHTTP.NewGoHTTP()
+	if refresh == nil {
+		refresh = &tokenRefresh{done: make(chan struct{})}
+		c.refresh = refresh
 
-	goHttp.AddHeaders(map[string]string{
-		"clientId":     clientId,
-		"clientSecret": clientSecret,
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return "", err
+		go c.refreshAuthToken(context.WithoutCancel(ctx), refresh)
 	}
 
-	var responseData = map[string]interface{}{}
+	c.mutex.Unlock()
 
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
-		return "", err
+	select {
+	case <-refresh.done:
+		return refresh.token, refresh.err
+	case <-ctx.Done():
+		return "", ctx.Err()
 	}
+}
 
-	if statusCode != 200 {
-		return "", errors.New(responseData["message"].(string))
-	}
+// tokenRefresh is a token request in flight. The token and err are set before done is closed.
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
 
-	token := responseData["token"].(string)
-	expires := responseData["expires"].(string)
+func (c *client) refreshAuthToken(ctx context.Context, refresh *tokenRefresh) {
+	token, expires, err := c.fetchAuthToken(ctx)
 
-	mutex.Lock()
-	defer mutex.Unlock()
-	authToken = token
-	authTokenExpires, err = time.Parse(time.RFC3339, expires)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
-	if err != nil {
-		fmt.Println("error parsing expires", expires, err)
+	if err == nil {
+		c.authToken = token
+		c.authTokenExpires = expires
 	}
 
-	fmt.Println("new token", authToken, "expires", authTokenExpires, "currentTime", currentTime)
-	return token, nil
+	c.refresh = nil
+	refresh.token = token
+	refresh.err = err
+	close(refresh.done)
 }
 
-// List function to fetch your eversend wallets and their balances
-func (e *Wallet) List() ([]interface{}, error) {
-	token, err := generateAuthToken()
+// fetchAuthToken requests a new auth token with the credentials of the provider.
+// When the API rejects credentials cached by the provider, they are fetched again and sent a second time.
+func (c *client) fetchAuthToken(ctx context.Context) (string, time.Time, error) {
+	var body []byte
+	var statusCode int
 
-	if err != nil {
-		return nil, err
-	}
+	for attempt := 1; ; attempt++ {
+		credentials, err := c.credentials.Credentials()
 
-	url := baseUrl + "wallets"
+		if err != nil {
+			return "", time.Time{}, err
+		}
 
-	goHttp := GoHTTP.NewGoHTTP()
+		body, statusCode, err = c.send(ctx, "auth.token", attempt, http.MethodGet, "auth/token", map[string]string{
+			"clientId":     credentials.ClientID,
+			"clientSecret": credentials.ClientSecret,
+		}, nil)
 
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-	})
+		if err != nil {
+			return "", time.Time{}, err
+		}
 
-	body, statusCode, err := goHttp.Get(url)
+		invalidator, ok := c.credentials.(CredentialsInvalidator)
 
-	if err != nil {
-		return nil, err
+		if statusCode == http.StatusUnauthorized && ok && attempt == 1 {
+			invalidator.Invalidate()
+			continue
+		}
+
+		break
 	}
 
-	var responseData = map[string]interface{}{}
+	if statusCode != 200 {
+		return "", time.Time{}, statusError(statusCode, body)
+	}
+
+	var responseData struct {
+		Token   string `json:"token"`
+		Expires string `json:"expires"`
+	}
 
-	err = json.Unmarshal(body, &responseData)
+	err := json.Unmarshal(body, &responseData)
 
 	if err != nil {
-		return nil, err
+		return "", time.Time{}, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
+	if responseData.Token == "" {
+		return "", time.Time{}, errors.New("eversend: auth token missing from response")
 	}
 
-	data := responseData["data"].([]interface{})
+	expires, err := time.Parse(time.RFC3339, responseData.Expires)
+
+	if err != nil {
+		// without a valid expiry the token is used for this request only
+		expires = time.Time{}
+	}
 
-	return data, nil
+	return responseData.Token, expires, nil
 }
 
-// Find function to fetch a specific Wallet and its balance
-// The walletCurrency is the currency of the Wallet you want to get e.g "UGX"
-func (e *Wallet) Find(walletCurrency string) (map[string]interface{}, error) {
-	token, err := generateAuthToken()
+// invalidateAuthToken drops the cached token after the API rejected it, unless it was already replaced.
+// The credentials cached by the provider are dropped too, as they may have been revoked with the token.
+func (c *client) invalidateAuthToken(token string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
-	if err != nil {
-		return nil, err
+	if c.authToken != token {
+		return
 	}
 
-	url := baseUrl + "wallets/" + walletCurrency
+	c.authToken = ""
+	c.authTokenExpires = time.Time{}
 
-	goHttp := GoHTTP.NewGoHTTP()
+	if invalidator, ok := c.credentials.(CredentialsInvalidator); ok {
+		invalidator.Invalidate()
+	}
+}
 
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-	})
+// dataResponse is the response of the endpoints whose data is a JSON object.
+type dataResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
 
-	body, statusCode, err := goHttp.Get(url)
+// listResponse is the response of the endpoints whose data is a JSON array.
+type listResponse struct {
+	Data []interface{} `json:"data"`
+}
 
-	if err != nil {
+// List function to fetch your eversend wallets and their balances
+func (e *Wallet) List() ([]interface{}, error) {
+	return e.ListContext(context.Background())
+}
+
+// ListContext is like List but sends the requests with ctx.
+func (e *Wallet) ListContext(ctx context.Context) ([]interface{}, error) {
+	var responseData listResponse
+
+	if err := e.client().call(ctx, "wallets.list", http.MethodGet, "wallets", nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	var responseData = map[string]interface{}{}
+	return responseData.Data, nil
+}
 
-	err = json.Unmarshal(body, &responseData)
+// Find function to fetch a specific Wallet and its balance
+// The walletCurrency is the currency of the Wallet you want to get e.g "UGX"
+func (e *Wallet) Find(walletCurrency string) (map[string]interface{}, error) {
+	return e.FindContext(context.Background(), walletCurrency)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// FindContext is like Find but sends the requests with ctx.
+func (e *Wallet) FindContext(ctx context.Context, walletCurrency string) (map[string]interface{}, error) {
+	var responseData dataResponse
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
+	if err := e.client().call(ctx, "wallets.get", http.MethodGet, "wallets/"+walletCurrency, nil, &responseData); err != nil {
+		return nil, err
 	}
 
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+	return responseData.Data, nil
 }
 
 // Quotation function to create an exchange quotation. This is used to get the amount you will receive when you convert money from one currency to another.
@@ -193,211 +255,106 @@ func (e *Wallet) Find(walletCurrency string) (map[string]interface{}, error) {
 // The from is the currency you want to convert from e.g "UGX".
 // The to is the currency you want to convert to e.g "KES".
 func (e *Exchange) Quotation(from string, amount float64, to string) (map[string]interface{}, error) {
-	url := baseUrl + "exchanges/quotation"
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
+	return e.QuotationContext(context.Background(), from, amount, to)
+}
 
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
+// QuotationContext is like Quotation but sends the requests with ctx.
+func (e *Exchange) QuotationContext(ctx context.Context, from string, amount float64, to string) (map[string]interface{}, error) {
+	var responseData dataResponse
 
-	reqBody, err := json.Marshal(map[string]interface{}{
+	err := e.client().call(ctx, "exchange.quote", http.MethodPost, "exchanges/quotation", map[string]interface{}{
 		"from":   from,
 		"amount": amount,
 		"to":     to,
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	body, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
+	}, &responseData)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
+	e.client().recordQuotation(responseData.Data, "baseCurrency", "baseAmount")
 
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+	return responseData.Data, nil
 }
 
 // Exchange function to create an exchange transaction. This is used to convert money from one currency to another.
 // The exchange token is used to identify the transaction. The exchange token is got from the CreateExchangeQuotation function
 func (e *Exchange) Exchange(exchangeToken string) (map[string]interface{}, error) {
-	url := baseUrl + "exchanges"
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	reqBody := []byte(fmt.Sprintf(`{"token": "%s"}`, exchangeToken))
-
-	body, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
+	return e.ExchangeContext(context.Background(), exchangeToken)
+}
 
-	if err != nil {
+// ExchangeContext is like Exchange but sends the requests with ctx.
+func (e *Exchange) ExchangeContext(ctx context.Context, exchangeToken string) (map[string]interface{}, error) {
+	if err := e.client().checkFunds(ctx, exchangeToken); err != nil {
 		return nil, err
 	}
 
-	var responseData = map[string]interface{}{}
+	var responseData dataResponse
 
-	err = json.Unmarshal(body, &responseData)
+	err := e.client().call(ctx, "exchange.create", http.MethodPost, "exchanges", map[string]interface{}{
+		"token": exchangeToken,
+	}, &responseData)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
+	e.client().forgetQuotation(exchangeToken)
 
-	return data, nil
+	return responseData.Data, nil
 }
 
 // AccountProfile function to get account profile details
 func (e *Eversend) AccountProfile() (map[string]interface{}, error) {
-	url := baseUrl + "account"
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
+	return e.AccountProfileContext(context.Background())
+}
 
-	err = json.Unmarshal(body, &responseData)
+// AccountProfileContext is like AccountProfile but sends the requests with ctx.
+func (e *Eversend) AccountProfileContext(ctx context.Context) (map[string]interface{}, error) {
+	var responseData dataResponse
 
-	if err != nil {
+	if err := e.client().call(ctx, "account.get", http.MethodGet, "account", nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+	return responseData.Data, nil
 }
 
 // DeliveryCountries function to get delivery countries. This are the countries you can send money to currently
 func (e *Payout) DeliveryCountries() ([]interface{}, error) {
-	url := baseUrl + "payouts/countries"
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
+	return e.DeliveryCountriesContext(context.Background())
+}
 
-	if err != nil {
-		return nil, err
+// DeliveryCountriesContext is like DeliveryCountries but sends the requests with ctx.
+func (e *Payout) DeliveryCountriesContext(ctx context.Context) ([]interface{}, error) {
+	var responseData struct {
+		Data struct {
+			Countries []interface{} `json:"countries"`
+		} `json:"data"`
 	}
 
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
+	if err := e.client().call(ctx, "payouts.countries", http.MethodGet, "payouts/countries", nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data["countries"].([]interface{}), nil
+	return responseData.Data.Countries, nil
 }
 
 // DeliveryBanks function to get delivery banks. This are the banks you can send money to in a specific country.
 // The countryCode is the Alpha-2 country code of the country you want to get the banks for.
 func (e *Payout) DeliveryBanks(countryCode string) ([]interface{}, error) {
-	url := baseUrl + "payouts/banks/" + countryCode
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
+	return e.DeliveryBanksContext(context.Background(), countryCode)
+}
 
-	err = json.Unmarshal(body, &responseData)
+// DeliveryBanksContext is like DeliveryBanks but sends the requests with ctx.
+func (e *Payout) DeliveryBanksContext(ctx context.Context, countryCode string) ([]interface{}, error) {
+	var responseData listResponse
 
-	if err != nil {
+	if err := e.client().call(ctx, "payouts.banks", http.MethodGet, "payouts/banks/"+countryCode, nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].([]interface{})
-
-	return data, nil
+	return responseData.Data, nil
 }
 
 // Quotation function to create a Payout quotation. This is used to get the amount you will get and fees when you send money to a specific country.
@@ -405,6 +362,16 @@ func (e *Payout) DeliveryBanks(countryCode string) ([]interface{}, error) {
 // The Default is "SOURCE".
 // The transactionType can be "bank" or "momo".
 func (e *Payout) Quotation(sourceWallet string, amount float64,
+	transactionType string,
+	destinationCountry string,
+	destinationCurrency string,
+	amountType string) (map[string]interface{}, error) {
+	return e.QuotationContext(context.Background(), sourceWallet, amount, transactionType, destinationCountry,
+		destinationCurrency, amountType)
+}
+
+// QuotationContext is like Quotation but sends the requests with ctx.
+func (e *Payout) QuotationContext(ctx context.Context, sourceWallet string, amount float64,
 	transactionType string,
 	destinationCountry string,
 	destinationCurrency string,
@@ -417,531 +384,228 @@ func (e *Payout) Quotation(sourceWallet string, amount float64,
 		return nil, errors.New("amount cannot be negative")
 	}
 
-	url := baseUrl + "payouts/quotation"
-	token, err := generateAuthToken()
+	var responseData dataResponse
 
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	reqBody := []byte(fmt.Sprintf(`{"sourceWallet": "%s", "amount": %f, "type": "%s", "destinationCountry": "%s", "destinationCurrency": "%s", "amountType": "%s"}`,
-		sourceWallet, amount, transactionType, destinationCountry, destinationCurrency, amountType))
-
-	body, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
+	err := e.client().call(ctx, "payouts.quote", http.MethodPost, "payouts/quotation", map[string]interface{}{
+		"sourceWallet":        sourceWallet,
+		"amount":              amount,
+		"type":                transactionType,
+		"destinationCountry":  destinationCountry,
+		"destinationCurrency": destinationCurrency,
+		"amountType":          amountType,
+	}, &responseData)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
+	e.client().recordQuotation(responseData.Data, "sourceCurrency", "sourceAmount")
 
-	if err != nil {
-		return nil, err
-	}
-
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+	return responseData.Data, nil
 }
 
 // MomoPayout function to create a mobile money(momo) Payout transaction. This is used to send money to a mobile money account of the recipient.
 func (e *Payout) MomoPayout(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
-	url := baseUrl + "payouts"
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	reqBody := []byte(fmt.Sprintf(`{"token": "%s", "phoneNumber": "%s", "firstName": "%s", "lastName": "%s", "country": "%s"}`,
-		payoutToken, phoneNumber, firstName, lastName, countryCode))
-
-	body, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
+	return e.MomoPayoutContext(context.Background(), payoutToken, phoneNumber, firstName, lastName, countryCode)
+}
 
-	if err != nil {
+// MomoPayoutContext is like MomoPayout but sends the requests with ctx.
+func (e *Payout) MomoPayoutContext(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error) {
+	if err := e.client().checkFunds(ctx, payoutToken); err != nil {
 		return nil, err
 	}
 
-	var responseData = map[string]interface{}{}
+	var responseData dataResponse
 
-	err = json.Unmarshal(body, &responseData)
+	err := e.client().call(ctx, "payouts.create", http.MethodPost, "payouts", map[string]interface{}{
+		"token":       payoutToken,
+		"phoneNumber": phoneNumber,
+		"firstName":   firstName,
+		"lastName":    lastName,
+		"country":     countryCode,
+	}, &responseData)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
+	e.client().forgetQuotation(payoutToken)
 
-	data := responseData["data"].(map[string]interface{})
-	return data, nil
+	return responseData.Data, nil
 }
 
 // BankPayout function to create a bank Payout transaction. This is used to send money to a bank account of the recipient.
 func (e *Payout) BankPayout(payoutToken string, phoneNumber string, firstName string, lastName string,
 	countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error) {
-	url := baseUrl + "payouts"
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	reqBody := []byte(fmt.Sprintf(`{"token": "%s", "phoneNumber": "%s", "firstName": "%s", "lastName": "%s", "country": "%s", "bankName": "%s", "bankAccountName": "%s", "bankCode": "%s", "bankAccountNumber": "%s"}`,
-		payoutToken, phoneNumber, firstName, lastName, countryCode, bankName, bankAccountName, bankCode, bankAccountNumber))
-
-	body, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
+	return e.BankPayoutContext(context.Background(), payoutToken, phoneNumber, firstName, lastName, countryCode, bankName,
+		bankAccountName, bankCode, bankAccountNumber)
+}
 
-	if err != nil {
+// BankPayoutContext is like BankPayout but sends the requests with ctx.
+func (e *Payout) BankPayoutContext(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string,
+	countryCode string, bankName string, bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error) {
+	if err := e.client().checkFunds(ctx, payoutToken); err != nil {
 		return nil, err
 	}
 
-	var responseData = map[string]interface{}{}
+	var responseData dataResponse
 
-	err = json.Unmarshal(body, &responseData)
+	err := e.client().call(ctx, "payouts.create", http.MethodPost, "payouts", map[string]interface{}{
+		"token":             payoutToken,
+		"phoneNumber":       phoneNumber,
+		"firstName":         firstName,
+		"lastName":          lastName,
+		"country":           countryCode,
+		"bankName":          bankName,
+		"bankAccountName":   bankAccountName,
+		"bankCode":          bankCode,
+		"bankAccountNumber": bankAccountNumber,
+	}, &responseData)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
+	e.client().forgetQuotation(payoutToken)
 
-	data := responseData["data"].(map[string]interface{})
-	return data, nil
+	return responseData.Data, nil
 }
 
 // Transaction function to get a transaction details.
 // The transactionId is the id of the transaction you want to get details for.
 func (e *Payout) Transaction(transactionId string) (map[string]interface{}, error) {
-	url := baseUrl + "transactions/" + transactionId
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
+	return e.TransactionContext(context.Background(), transactionId)
+}
 
-	err = json.Unmarshal(body, &responseData)
+// TransactionContext is like Transaction but sends the requests with ctx.
+func (e *Payout) TransactionContext(ctx context.Context, transactionId string) (map[string]interface{}, error) {
+	var responseData dataResponse
 
-	if err != nil {
+	if err := e.client().call(ctx, "transactions.get", http.MethodGet, "transactions/"+transactionId, nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-	return data, nil
+	return responseData.Data, nil
 }
 
 // CreateMomoBeneficiary function to create a mobile money beneficiary. This is used to save a mobile money account for future use.
+// It returns the created beneficiary including its ID.
 // countryCode is the Alpha-2 country code of the country e.g "UG".
-func (e *Beneficiary) CreateMomoBeneficiary(firstName string, lastname string, countryCode string, phoneNumber string) error {
-	url := baseUrl + "beneficiaries"
-
-	token, err := generateAuthToken()
+// With WithDuplicateBeneficiaryCheck, a *DuplicateBeneficiaryError is returned if a momo beneficiary with the same phone
+// number already exists in the country.
+func (e *Beneficiary) CreateMomoBeneficiary(firstName string, lastname string, countryCode string, phoneNumber string) (*BeneficiaryDetails, error) {
+	return e.CreateMomoBeneficiaryContext(context.Background(), firstName, lastname, countryCode, phoneNumber)
+}
 
-	if err != nil {
-		return err
+// CreateMomoBeneficiaryContext is like CreateMomoBeneficiary but sends the requests with ctx.
+func (e *Beneficiary) CreateMomoBeneficiaryContext(ctx context.Context, firstName string, lastname string, countryCode string, phoneNumber string) (*BeneficiaryDetails, error) {
+	if err := e.checkDuplicate(ctx, BeneficiaryDetails{
+		Country:     countryCode,
+		PhoneNumber: phoneNumber,
+		IsMomo:      true,
+	}); err != nil {
+		return nil, err
 	}
 
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	reqBody := []byte(fmt.Sprintf(`{"firstName": "%s", "lastName": "%s", "country": "%s", "phoneNumber": "%s","isBank": false,"isMomo": true}`,
-		firstName, lastname, countryCode, phoneNumber))
-
-	respBody, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
-
-	if err != nil {
-		return err
+	var responseData struct {
+		Data BeneficiaryDetails `json:"data"`
 	}
 
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(respBody, &responseData)
+	err := e.client().call(ctx, "beneficiaries.create", http.MethodPost, "beneficiaries", map[string]interface{}{
+		"firstName":   firstName,
+		"lastName":    lastname,
+		"country":     countryCode,
+		"phoneNumber": phoneNumber,
+		"isBank":      false,
+		"isMomo":      true,
+	}, &responseData)
 
 	if err != nil {
-		return err
-	}
-
-	if statusCode != 200 {
-		return errors.New(responseData["message"].(string))
+		return nil, err
 	}
 
-	return nil
+	return &responseData.Data, nil
 }
 
 // CreateBankBeneficiary function to create a bank beneficiary. This is used to save a bank account for future use.
+// It returns the created beneficiary including its ID.
 // bankCode is got from the GetDeliveryBanks function.
 // countryCode is the Alpha-2 country code of the country e.g "UG".
+// With WithDuplicateBeneficiaryCheck, a *DuplicateBeneficiaryError is returned if a bank beneficiary with the same bank
+// code and account number already exists in the country.
 func (e *Beneficiary) CreateBankBeneficiary(firstName string, lastname string, countryCode string, bankName string,
-	bankAccountName string, bankCode string, bankAccountNumber string) error {
-	url := baseUrl + "beneficiaries"
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	reqBody := []byte(fmt.Sprintf(`{"firstName": "%s", "lastName": "%s", "country": "%s", "bankName": "%s", "bankAccountName": "%s", "bankCode": "%s", "bankAccountNumber": "%s","isBank": true,"isMomo": true}`,
-		firstName, lastname, countryCode, bankName, bankAccountName, bankCode, bankAccountNumber))
-
-	respBody, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
-
-	if err != nil {
-		return err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(respBody, &responseData)
-
-	if err != nil {
-		return err
-	}
-
-	if statusCode != 200 {
-		return errors.New(responseData["message"].(string))
-	}
-
-	return nil
-}
-
-// List function to get a list of beneficiaries. This is used to get the beneficiaries you have saved.
-func (e *Beneficiary) List() ([]interface{}, error) {
-	url := baseUrl + "beneficiaries"
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	beneficiaries := data["beneficiaries"].([]interface{})
-
-	return beneficiaries, nil
+	bankAccountName string, bankCode string, bankAccountNumber string) (*BeneficiaryDetails, error) {
+	return e.CreateBankBeneficiaryContext(context.Background(), firstName, lastname, countryCode, bankName, bankAccountName,
+		bankCode, bankAccountNumber)
 }
 
-// Find function to get a beneficiary details. This is used to get the details of a specific Beneficiary.
-func (e *Beneficiary) Find(beneficiaryId string) (map[string]interface{}, error) {
-	url := baseUrl + "beneficiaries/" + beneficiaryId
-	token, err := generateAuthToken()
-
-	if err != nil {
+// CreateBankBeneficiaryContext is like CreateBankBeneficiary but sends the requests with ctx.
+func (e *Beneficiary) CreateBankBeneficiaryContext(ctx context.Context, firstName string, lastname string, countryCode string, bankName string,
+	bankAccountName string, bankCode string, bankAccountNumber string) (*BeneficiaryDetails, error) {
+	if err := e.checkDuplicate(ctx, BeneficiaryDetails{
+		Country:           countryCode,
+		BankCode:          bankCode,
+		BankAccountNumber: bankAccountNumber,
+		IsBank:            true,
+	}); err != nil {
 		return nil, err
 	}
 
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
+	var responseData struct {
+		Data BeneficiaryDetails `json:"data"`
 	}
 
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
+	err := e.client().call(ctx, "beneficiaries.create", http.MethodPost, "beneficiaries", map[string]interface{}{
+		"firstName":         firstName,
+		"lastName":          lastname,
+		"country":           countryCode,
+		"bankName":          bankName,
+		"bankAccountName":   bankAccountName,
+		"bankCode":          bankCode,
+		"bankAccountNumber": bankAccountNumber,
+		"isBank":            true,
+		"isMomo":            false,
+	}, &responseData)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+	return &responseData.Data, nil
 }
 
-// AssetChains function to get a list of asset chains. This is used to get the asset chains you can use to send money.
-// The coin is the currency you want to get the asset chains for e.g "USDT".
-func (e *Crypto) AssetChains(coin string) (map[string]interface{}, error) {
-	url := baseUrl + "crypto/assets/" + coin
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	return responseData, nil
+// List function to get a list of beneficiaries. This is used to get the beneficiaries you have saved.
+func (e *Beneficiary) List() ([]interface{}, error) {
+	return e.ListContext(context.Background())
 }
 
-// Addresses function to get a list of addresses. This is used to get the addresses you have saved.
-func (e *Crypto) Addresses() (map[string]interface{}, error) {
-	url := baseUrl + "crypto/addresses"
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
+// ListContext is like List but sends the requests with ctx.
+func (e *Beneficiary) ListContext(ctx context.Context) ([]interface{}, error) {
+	var responseData struct {
+		Data struct {
+			Beneficiaries []interface{} `json:"beneficiaries"`
+		} `json:"data"`
 	}
 
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
+	if err := e.client().call(ctx, "beneficiaries.list", http.MethodGet, "beneficiaries", nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	return responseData, nil
+	return responseData.Data.Beneficiaries, nil
 }
 
-// GetTransactions function to get a list of crypto transactions. This is used to get the transactions you have made.
-func (e *Crypto) Transactions() (map[string]interface{}, error) {
-	url := baseUrl + "crypto/transactions"
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
-}
-
-// AddressTransactions function to get a list of transactions for a specific address. This is used to get the transactions for a specific address.
-func (e *Crypto) AddressTransactions(cryptoCoinAddress string) (map[string]interface{}, error) {
-	url := baseUrl + "crypto/addresses/" + cryptoCoinAddress + "/transactions"
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-	})
-
-	body, statusCode, err := goHttp.Get(url)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+// Find function to get a beneficiary details. This is used to get the details of a specific Beneficiary.
+func (e *Beneficiary) Find(beneficiaryId string) (map[string]interface{}, error) {
+	return e.FindContext(context.Background(), beneficiaryId)
 }
 
-// CreateAddress function to create a crypto address. This is used to create a crypto address for a specific coin.
-// The assetId is the id of the asset you want to create the address for. Valid asset from the GetAssetChains function.
-// The ownerName is the name of the owner of the address.
-// The destinationAddressDescription is the description of the address. Should be Client email or unique identifier.
-func (e *Crypto) CreateAddress(assetId string, ownerName string, destinationAddressDescription string, purpose string) (map[string]interface{}, error) {
-	url := baseUrl + "crypto/addresses"
-
-	token, err := generateAuthToken()
-
-	if err != nil {
-		return nil, err
-	}
-	reqBody := []byte(fmt.Sprintf(`{"assetId": "%s", "ownerName": "%s", "destinationAddressDescription": "%s", "purpose": "%s"}`,
-		assetId, ownerName, destinationAddressDescription, purpose))
-
-	goHttp := GoHTTP.NewGoHTTP()
-
-	goHttp.AddHeaders(map[string]string{
-		"Authorization": "Bearer " + token,
-		"Content-Type":  "application/json",
-	})
+// FindContext is like Find but sends the requests with ctx.
+func (e *Beneficiary) FindContext(ctx context.Context, beneficiaryId string) (map[string]interface{}, error) {
+	var responseData dataResponse
 
-	body, statusCode, err := goHttp.Post(url, bytes.NewBuffer(reqBody))
-
-	if err != nil {
-		return nil, err
-	}
-
-	var responseData = map[string]interface{}{}
-
-	err = json.Unmarshal(body, &responseData)
-
-	if err != nil {
+	if err := e.client().call(ctx, "beneficiaries.get", http.MethodGet, "beneficiaries/"+beneficiaryId, nil, &responseData); err != nil {
 		return nil, err
 	}
 
-	if statusCode != 200 {
-		return nil, errors.New(responseData["message"].(string))
-	}
-
-	data := responseData["data"].(map[string]interface{})
-
-	return data, nil
+	return responseData.Data, nil
 }
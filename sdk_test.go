@@ -0,0 +1,745 @@
+package eversendSdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/eversendtest"
+)
+
+func newTestApp(t *testing.T, opts ...eversendtest.Option) (*Eversend, *eversendtest.Server) {
+	t.Helper()
+
+	srv := eversendtest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	return NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL())), srv
+}
+
+// newStubApp returns an app whose API issues tokens and answers every other request with handler.
+func newStubApp(t *testing.T, handler http.HandlerFunc) *Eversend {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/token" {
+			w.Write([]byte(`{"token": "stub-token", "expires": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewEversendApp("id", "secret", WithBaseUrl(srv.URL+"/v1/"))
+}
+
+// expireToken makes the cached auth token of the app expired.
+func expireToken(app *Eversend) {
+	c := app.client()
+
+	c.mutex.Lock()
+	c.authTokenExpires = time.Now().Add(-time.Minute)
+	c.mutex.Unlock()
+}
+
+// endpointCalls calls every method that sends a request, for the error path tests.
+var endpointCalls = []struct {
+	name string
+	call func(app *Eversend) error
+}{
+	{"AccountProfile", func(app *Eversend) error { _, err := app.AccountProfile(); return err }},
+	{"Wallets.List", func(app *Eversend) error { _, err := app.Wallets.List(); return err }},
+	{"Wallets.Find", func(app *Eversend) error { _, err := app.Wallets.Find("UGX"); return err }},
+	{"Exchange.Quotation", func(app *Eversend) error { _, err := app.Exchange.Quotation("UGX", 1000, "KES"); return err }},
+	{"Exchange.Exchange", func(app *Eversend) error { _, err := app.Exchange.Exchange("token"); return err }},
+	{"Payouts.DeliveryCountries", func(app *Eversend) error { _, err := app.Payouts.DeliveryCountries(); return err }},
+	{"Payouts.DeliveryBanks", func(app *Eversend) error { _, err := app.Payouts.DeliveryBanks("UG"); return err }},
+	{"Payouts.Quotation", func(app *Eversend) error {
+		_, err := app.Payouts.Quotation("UGX", 1000, "momo", "KE", "KES", "")
+		return err
+	}},
+	{"Payouts.MomoPayout", func(app *Eversend) error {
+		_, err := app.Payouts.MomoPayout("token", "+254712345678", "Jane", "Doe", "KE")
+		return err
+	}},
+	{"Payouts.BankPayout", func(app *Eversend) error {
+		_, err := app.Payouts.BankPayout("token", "+256712345678", "Jane", "Doe", "UG", "Stanbic", "Jane Doe", "SBICUGKX", "0123456789")
+		return err
+	}},
+	{"Payouts.Transaction", func(app *Eversend) error { _, err := app.Payouts.Transaction("BE123"); return err }},
+	{"Beneficiaries.CreateMomoBeneficiary", func(app *Eversend) error {
+		_, err := app.Beneficiaries.CreateMomoBeneficiary("Jane", "Doe", "UG", "+256712345678")
+		return err
+	}},
+	{"Beneficiaries.CreateBankBeneficiary", func(app *Eversend) error {
+		_, err := app.Beneficiaries.CreateBankBeneficiary("Jane", "Doe", "UG", "Stanbic", "Jane Doe", "SBICUGKX", "0123456789")
+		return err
+	}},
+	{"Beneficiaries.List", func(app *Eversend) error { _, err := app.Beneficiaries.List(); return err }},
+	{"Beneficiaries.Find", func(app *Eversend) error { _, err := app.Beneficiaries.Find("1"); return err }},
+	{"Beneficiaries.Update", func(app *Eversend) error {
+		_, err := app.Beneficiaries.Update("1", BeneficiaryUpdate{LastName: "Roe"})
+		return err
+	}},
+	{"Beneficiaries.Delete", func(app *Eversend) error { return app.Beneficiaries.Delete("1") }},
+	{"Beneficiaries.Search", func(app *Eversend) error {
+		_, err := app.Beneficiaries.Search(BeneficiarySearch{Name: "Jane"})
+		return err
+	}},
+	{"Crypto.AssetChains", func(app *Eversend) error { _, err := app.Crypto.AssetChains("USDT"); return err }},
+	{"Crypto.Addresses", func(app *Eversend) error { _, err := app.Crypto.Addresses(); return err }},
+	{"Crypto.Transactions", func(app *Eversend) error { _, err := app.Crypto.Transactions(); return err }},
+	{"Crypto.AddressTransactions", func(app *Eversend) error {
+		_, err := app.Crypto.AddressTransactions("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+		return err
+	}},
+	{"Crypto.CreateAddress", func(app *Eversend) error {
+		_, err := app.Crypto.CreateAddress("USDT_TRON", "Jane", "jane@example.com", "deposits")
+		return err
+	}},
+	{"Collections.Fees", func(app *Eversend) error {
+		_, err := app.Collections.Fees(CollectionFeesRequest{Method: "momo", Currency: "UGX", Amount: 1000})
+		return err
+	}},
+	{"Collections.RequestOTP", func(app *Eversend) error { _, err := app.Collections.RequestOTP("+256712345678"); return err }},
+	{"Collections.VerifyOTP", func(app *Eversend) error { return app.Collections.VerifyOTP("pin", "123456") }},
+	{"Collections.Momo", func(app *Eversend) error {
+		_, err := app.Collections.Momo(MomoCollectionRequest{Phone: "+256712345678", Amount: 1000, Country: "UG", Currency: "UGX"})
+		return err
+	}},
+	{"Collections.Status", func(app *Eversend) error { _, err := app.Collections.Status("BE123"); return err }},
+}
+
+func TestEndpointsReturnErrorMessage(t *testing.T) {
+	app := newStubApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": 400, "message": "Something went wrong"}`))
+	})
+
+	for _, tc := range endpointCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call(app)
+
+			if err == nil || err.Error() != "Something went wrong" {
+				t.Fatalf("got error %v, want the message of the response", err)
+			}
+
+			var apiErr *APIError
+
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+				t.Fatalf("got error %#v, want an *APIError with the status code", err)
+			}
+		})
+	}
+}
+
+func TestEndpointsWithoutErrorMessage(t *testing.T) {
+	app := newStubApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"code": 500}`))
+	})
+
+	for _, tc := range endpointCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call(app)
+
+			if err == nil || !strings.Contains(err.Error(), "500") {
+				t.Fatalf("got error %v, want an error with the status code", err)
+			}
+		})
+	}
+}
+
+func TestEndpointsWithMalformedJSON(t *testing.T) {
+	app := newStubApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": `))
+	})
+
+	for _, tc := range endpointCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(app); err == nil {
+				t.Fatal("got no error for a malformed response")
+			}
+		})
+	}
+}
+
+func TestEndpointsWhenTokenFails(t *testing.T) {
+	app, srv := newTestApp(t)
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultServerError, Path: "auth/token"})
+
+	for _, tc := range endpointCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(app); err == nil {
+				t.Fatal("got no error when the auth token could not be generated")
+			}
+		})
+	}
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	srv := eversendtest.NewServer()
+	defer srv.Close()
+
+	app := NewEversendApp("wrong", "credentials", WithBaseUrl(srv.BaseURL()))
+
+	_, err := app.Wallets.List()
+
+	if err == nil || err.Error() != "Invalid client credentials" {
+		t.Fatalf("got error %v, want Invalid client credentials", err)
+	}
+}
+
+func TestTokenIsReused(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	for i := 0; i < 3; i++ {
+		if _, err := app.Wallets.List(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 1 {
+		t.Fatalf("got %d token requests, want 1", got)
+	}
+}
+
+func TestExpiredTokenIsRefreshed(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	expireToken(app)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests, want 2", got)
+	}
+}
+
+func TestTokenRejectedByServer(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the cached token is revoked before it expires
+	srv.ExpireTokens()
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests, want 2", got)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "wallets"); got != 3 {
+		t.Fatalf("got %d wallet requests, want the rejected one sent again", got)
+	}
+}
+
+func TestTokenRejectedTwice(t *testing.T) {
+	var requests int32
+
+	app := newStubApp(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Unauthorized"}`))
+	})
+
+	if _, err := app.Wallets.List(); err == nil || err.Error() != "Unauthorized" {
+		t.Fatalf("got error %v, want Unauthorized", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("got %d requests, want one retry", requests)
+	}
+}
+
+func TestNewEversendAppResetsToken(t *testing.T) {
+	_, srv := newTestApp(t)
+
+	for i := 0; i < 2; i++ {
+		app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()))
+
+		if _, err := app.Wallets.List(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests, want 2", got)
+	}
+}
+
+func TestConcurrentTokenRefresh(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	find := func() {
+		var wg sync.WaitGroup
+		errs := make(chan error, 50)
+
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := app.Wallets.Find("UGX")
+				errs <- err
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	find()
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 1 {
+		t.Fatalf("got %d token requests, want 1", got)
+	}
+
+	expireToken(app)
+	find()
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests after the token expired, want 2", got)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "wallets/UGX"); got != 100 {
+		t.Fatalf("got %d wallet requests, want 100", got)
+	}
+}
+
+func TestTokenRequestOutlivesCancelledCall(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultTimeout, Path: "auth/token", Delay: 100 * time.Millisecond, Times: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := app.Wallets.ListContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+
+	// the token request started by the cancelled call is shared by the next one
+	if _, err := app.Wallets.List(); err == nil || err.Error() != "Gateway Timeout" {
+		t.Fatalf("got error %v, want the result of the shared token request", err)
+	}
+
+	if _, err := app.Wallets.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := srv.RequestCount(http.MethodGet, "auth/token"); got != 2 {
+		t.Fatalf("got %d token requests, want 2", got)
+	}
+}
+
+func TestAccountProfile(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	profile, err := app.AccountProfile()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if profile["businessName"] != "Test Business" {
+		t.Fatalf("got profile %v", profile)
+	}
+}
+
+func TestWalletsList(t *testing.T) {
+	app, _ := newTestApp(t, eversendtest.WithBalance("UGX", 5000))
+
+	wallets, err := app.Wallets.List()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, wallet := range wallets {
+		w := wallet.(map[string]interface{})
+
+		if w["currency"] == "UGX" {
+			found = w["amount"] == 5000.0
+		}
+	}
+
+	if !found {
+		t.Fatalf("got wallets %v, want UGX with 5000", wallets)
+	}
+}
+
+func TestWalletsFind(t *testing.T) {
+	app, _ := newTestApp(t, eversendtest.WithBalance("KES", 750))
+
+	wallet, err := app.Wallets.Find("KES")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wallet["currency"] != "KES" || wallet["amount"] != 750.0 {
+		t.Fatalf("got wallet %v", wallet)
+	}
+
+	if _, err := app.Wallets.Find("XYZ"); err == nil || err.Error() != "Wallet not found" {
+		t.Fatalf("got error %v for an unknown wallet", err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	app, srv := newTestApp(t, eversendtest.WithBalance("UGX", 10000), eversendtest.WithBalance("KES", 0),
+		eversendtest.WithRate("UGX", "KES", 0.04))
+
+	quotation, err := app.Exchange.Quotation("UGX", 5000, "KES")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details := quotation["quotation"].(map[string]interface{})
+
+	if details["destAmount"] != 200.0 {
+		t.Fatalf("got quotation %v, want 200 KES", quotation)
+	}
+
+	transaction, err := app.Exchange.Exchange(quotation["token"].(string))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transaction["status"] != "completed" {
+		t.Fatalf("got transaction %v", transaction)
+	}
+
+	if srv.Balance("UGX") != 5000 || srv.Balance("KES") != 200 {
+		t.Fatalf("got balances UGX %v KES %v", srv.Balance("UGX"), srv.Balance("KES"))
+	}
+
+	if _, err := app.Exchange.Exchange(quotation["token"].(string)); err == nil {
+		t.Fatal("got no error reusing an exchange token")
+	}
+}
+
+func TestExchangeInsufficientFunds(t *testing.T) {
+	app, _ := newTestApp(t, eversendtest.WithBalance("UGX", 100))
+
+	quotation, err := app.Exchange.Quotation("UGX", 5000, "KES")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Exchange.Exchange(quotation["token"].(string)); err == nil || err.Error() != "Insufficient balance" {
+		t.Fatalf("got error %v, want Insufficient balance", err)
+	}
+}
+
+func TestDeliveryCountriesAndBanks(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	countries, err := app.Payouts.DeliveryCountries()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(countries) == 0 {
+		t.Fatal("got no delivery countries")
+	}
+
+	banks, err := app.Payouts.DeliveryBanks("UG")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(banks) == 0 {
+		t.Fatal("got no delivery banks")
+	}
+}
+
+func TestPayoutQuotation(t *testing.T) {
+	app, _ := newTestApp(t, eversendtest.WithRate("UGX", "KES", 0.035), eversendtest.WithPayoutFeeRate(0.01))
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details := quotation["quotation"].(map[string]interface{})
+
+	if details["amountType"] != "SOURCE" || details["destinationAmount"] != 350.0 || details["totalAmount"] != 10100.0 {
+		t.Fatalf("got quotation %v", quotation)
+	}
+
+	quotation, err = app.Payouts.Quotation("UGX", 350, "momo", "KE", "KES", "DESTINATION")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if details := quotation["quotation"].(map[string]interface{}); details["sourceAmount"] != 10000.0 {
+		t.Fatalf("got quotation %v", quotation)
+	}
+}
+
+func TestPayoutQuotationNegativeAmount(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	if _, err := app.Payouts.Quotation("UGX", -1, "momo", "KE", "KES", ""); err == nil {
+		t.Fatal("got no error for a negative amount")
+	}
+
+	if got := len(srv.Requests()); got != 0 {
+		t.Fatalf("got %d requests, want none", got)
+	}
+}
+
+func TestMomoPayoutAndTransaction(t *testing.T) {
+	app, srv := newTestApp(t, eversendtest.WithBalance("UGX", 20000), eversendtest.WithPayoutFeeRate(0.01))
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "SOURCE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payout, err := app.Payouts.MomoPayout(quotation["token"].(string), "+254712345678", "Jane", "Doe", "KE")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.Balance("UGX") != 9900 {
+		t.Fatalf("got UGX balance %v, want 9900", srv.Balance("UGX"))
+	}
+
+	transaction, err := app.Payouts.Transaction(payout["transactionId"].(string))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transaction["phoneNumber"] != "+254712345678" || transaction["fees"] != 100.0 {
+		t.Fatalf("got transaction %v", transaction)
+	}
+}
+
+func TestBankPayout(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "bank", "UG", "UGX", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payout, err := app.Payouts.BankPayout(quotation["token"].(string), "+256712345678", "Jane", "Doe", "UG",
+		"Stanbic Bank Uganda", "Jane Doe", "SBICUGKX", "0123456789")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payout["bankAccountNumber"] != "0123456789" || payout["bankCode"] != "SBICUGKX" {
+		t.Fatalf("got payout %v", payout)
+	}
+}
+
+func TestPayoutInsufficientFunds(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	quotation, err := app.Payouts.Quotation("UGX", 10000, "momo", "KE", "KES", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultInsufficientFunds, Method: http.MethodPost, Path: "payouts", Times: 1})
+
+	if _, err := app.Payouts.MomoPayout(quotation["token"].(string), "+254712345678", "Jane", "Doe", "KE"); err == nil {
+		t.Fatal("got no error for insufficient funds")
+	}
+}
+
+func TestCryptoEndpoints(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	asset, err := app.Crypto.AssetChains("USDT")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if chain, ok := asset.FindChain("USDT_TRON"); asset.Coin != "USDT" || !ok || chain.Chain != "TRON" {
+		t.Fatalf("got asset chains %+v", asset)
+	}
+
+	address, err := app.Crypto.CreateAddress("USDT_TRON", "Jane Doe", "jane@example.com", "deposits")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address.Address == "" || address.AssetID != "USDT_TRON" || address.Chain != "TRON" {
+		t.Fatalf("got address %+v", address)
+	}
+
+	if _, err := app.Crypto.CreateAddress("USDC_TRON", "John Roe", "john@example.com", "payouts"); err == nil {
+		t.Fatal("got no error for an asset the coin does not have")
+	}
+
+	if _, err := app.Crypto.CreateAddress("USDC_ETH", "John Roe", "john@example.com", "payouts"); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.AddCryptoDeposit(address.Address, 25, 3)
+
+	addresses, err := app.Crypto.Addresses()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(addresses) != 2 {
+		t.Fatalf("got addresses %+v", addresses)
+	}
+
+	found, err := app.Crypto.FindAddress(address.Address)
+
+	if err != nil || found.ID != address.ID {
+		t.Fatalf("got %+v and %v, want the created address", found, err)
+	}
+
+	if found, err := app.Crypto.FindAddress(address.ID); err != nil || found.Address != address.Address {
+		t.Fatalf("got %+v and %v finding the address by ID", found, err)
+	}
+
+	if _, err := app.Crypto.FindAddress("unknown"); !errors.Is(err, ErrAddressNotFound) {
+		t.Fatalf("got %v, want ErrAddressNotFound", err)
+	}
+
+	transactions, err := app.Crypto.Transactions()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transactions) != 1 || transactions[0].Amount != 25 || transactions[0].Confirmations != 3 || transactions[0].Status != "pending" {
+		t.Fatalf("got transactions %+v", transactions)
+	}
+
+	addressTransactions, err := app.Crypto.AddressTransactions(address.Address)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(addressTransactions) != 1 || addressTransactions[0].Address != address.Address {
+		t.Fatalf("got address transactions %+v", addressTransactions)
+	}
+
+	if _, err := app.Crypto.CreateAddress("UNKNOWN", "Jane Doe", "jane@example.com", "deposits"); err == nil {
+		t.Fatal("got no error for an unknown asset")
+	}
+}
+
+func TestSearchAddresses(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	for _, create := range []struct{ asset, owner, purpose string }{
+		{"USDT_TRON", "Jane Doe", "deposits"},
+		{"USDT_ETH", "Jane Doe", "payouts"},
+		{"USDT_TRON", "John Roe", "Deposits"},
+	} {
+		if _, err := app.Crypto.CreateAddress(create.asset, create.owner, "", create.purpose); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		query AddressSearch
+		want  int
+	}{
+		{AddressSearch{}, 3},
+		{AddressSearch{AssetID: "usdt_tron"}, 2},
+		{AddressSearch{OwnerName: "jane"}, 2},
+		{AddressSearch{Purpose: "deposits"}, 2},
+		{AddressSearch{AssetID: "USDT_TRON", OwnerName: "Jane", Purpose: "deposits"}, 1},
+		{AddressSearch{OwnerName: "Alice"}, 0},
+	} {
+		matches, err := app.Crypto.SearchAddresses(tc.query)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(matches) != tc.want {
+			t.Errorf("%+v: got %d addresses, want %d", tc.query, len(matches), tc.want)
+		}
+	}
+}
+
+func TestCreateAddressValidatesAsset(t *testing.T) {
+	app, srv := newTestApp(t)
+
+	_, err := app.Crypto.CreateAddress("USDT_SOL", "Jane Doe", "jane@example.com", "deposits")
+
+	var invalid *InvalidAssetError
+
+	if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalidAsset) {
+		t.Fatalf("got %v, want an *InvalidAssetError", err)
+	}
+
+	if strings.Join(invalid.Valid, ",") != "USDT_TRON,USDT_ETH,USDT_BSC" {
+		t.Fatalf("got valid assets %v", invalid.Valid)
+	}
+
+	if count := srv.RequestCount(http.MethodPost, "crypto/addresses"); count != 0 {
+		t.Fatalf("got %d addresses created for an invalid asset, want 0", count)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	srv := eversendtest.NewServer()
+	defer srv.Close()
+
+	app := NewEversendApp(srv.ClientID, srv.ClientSecret, WithBaseUrl(srv.BaseURL()),
+		WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+
+	srv.InjectFault(eversendtest.Fault{Kind: eversendtest.FaultTimeout, Path: "wallets", Delay: time.Second})
+
+	if _, err := app.Wallets.List(); err == nil {
+		t.Fatal("got no error for a request that timed out")
+	}
+}
@@ -0,0 +1,118 @@
+package eversendSdk
+
+import "context"
+
+//go:generate moq -out mocks/services.go -pkg mocks -rm . WalletService ExchangeService PayoutService BeneficiaryService CryptoService CollectionService TransactionService AccountService
+
+// WalletService is the set of wallet operations. It is implemented by Wallet.
+type WalletService interface {
+	List() ([]interface{}, error)
+	ListContext(ctx context.Context) ([]interface{}, error)
+	Find(walletCurrency string) (map[string]interface{}, error)
+	FindContext(ctx context.Context, walletCurrency string) (map[string]interface{}, error)
+}
+
+// ExchangeService is the set of currency exchange operations. It is implemented by Exchange.
+type ExchangeService interface {
+	Quotation(from string, amount float64, to string) (map[string]interface{}, error)
+	QuotationContext(ctx context.Context, from string, amount float64, to string) (map[string]interface{}, error)
+	Exchange(exchangeToken string) (map[string]interface{}, error)
+	ExchangeContext(ctx context.Context, exchangeToken string) (map[string]interface{}, error)
+}
+
+// PayoutService is the set of payout operations. It is implemented by Payout.
+type PayoutService interface {
+	DeliveryCountries() ([]interface{}, error)
+	DeliveryCountriesContext(ctx context.Context) ([]interface{}, error)
+	DeliveryBanks(countryCode string) ([]interface{}, error)
+	DeliveryBanksContext(ctx context.Context, countryCode string) ([]interface{}, error)
+	Quotation(sourceWallet string, amount float64, transactionType string, destinationCountry string,
+		destinationCurrency string, amountType string) (map[string]interface{}, error)
+	QuotationContext(ctx context.Context, sourceWallet string, amount float64, transactionType string, destinationCountry string,
+		destinationCurrency string, amountType string) (map[string]interface{}, error)
+	MomoPayout(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error)
+	MomoPayoutContext(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string) (map[string]interface{}, error)
+	BankPayout(payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string,
+		bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error)
+	BankPayoutContext(ctx context.Context, payoutToken string, phoneNumber string, firstName string, lastName string, countryCode string, bankName string,
+		bankAccountName string, bankCode string, bankAccountNumber string) (map[string]interface{}, error)
+	Transaction(transactionId string) (map[string]interface{}, error)
+	TransactionContext(ctx context.Context, transactionId string) (map[string]interface{}, error)
+}
+
+// BeneficiaryService is the set of beneficiary operations. It is implemented by Beneficiary.
+type BeneficiaryService interface {
+	CreateMomoBeneficiary(firstName string, lastname string, countryCode string, phoneNumber string) (*BeneficiaryDetails, error)
+	CreateMomoBeneficiaryContext(ctx context.Context, firstName string, lastname string, countryCode string, phoneNumber string) (*BeneficiaryDetails, error)
+	CreateBankBeneficiary(firstName string, lastname string, countryCode string, bankName string, bankAccountName string,
+		bankCode string, bankAccountNumber string) (*BeneficiaryDetails, error)
+	CreateBankBeneficiaryContext(ctx context.Context, firstName string, lastname string, countryCode string, bankName string, bankAccountName string,
+		bankCode string, bankAccountNumber string) (*BeneficiaryDetails, error)
+	List() ([]interface{}, error)
+	ListContext(ctx context.Context) ([]interface{}, error)
+	Find(beneficiaryId string) (map[string]interface{}, error)
+	FindContext(ctx context.Context, beneficiaryId string) (map[string]interface{}, error)
+	Update(beneficiaryId string, update BeneficiaryUpdate) (*BeneficiaryDetails, error)
+	UpdateContext(ctx context.Context, beneficiaryId string, update BeneficiaryUpdate) (*BeneficiaryDetails, error)
+	Delete(beneficiaryId string) error
+	DeleteContext(ctx context.Context, beneficiaryId string) error
+	Search(query BeneficiarySearch) ([]BeneficiaryDetails, error)
+	SearchContext(ctx context.Context, query BeneficiarySearch) ([]BeneficiaryDetails, error)
+	FindDuplicate(candidate BeneficiaryDetails) (*BeneficiaryDetails, error)
+	FindDuplicateContext(ctx context.Context, candidate BeneficiaryDetails) (*BeneficiaryDetails, error)
+}
+
+// CryptoService is the set of crypto operations. It is implemented by Crypto.
+type CryptoService interface {
+	AssetChains(coin string) (*CryptoAsset, error)
+	AssetChainsContext(ctx context.Context, coin string) (*CryptoAsset, error)
+	Addresses() ([]CryptoAddress, error)
+	AddressesContext(ctx context.Context) ([]CryptoAddress, error)
+	FindAddress(address string) (*CryptoAddress, error)
+	FindAddressContext(ctx context.Context, address string) (*CryptoAddress, error)
+	SearchAddresses(query AddressSearch) ([]CryptoAddress, error)
+	SearchAddressesContext(ctx context.Context, query AddressSearch) ([]CryptoAddress, error)
+	Transactions() ([]CryptoTransaction, error)
+	TransactionsContext(ctx context.Context) ([]CryptoTransaction, error)
+	AddressTransactions(cryptoCoinAddress string) ([]CryptoTransaction, error)
+	AddressTransactionsContext(ctx context.Context, cryptoCoinAddress string) ([]CryptoTransaction, error)
+	CreateAddress(assetId string, ownerName string, destinationAddressDescription string, purpose string) (*CryptoAddress, error)
+	CreateAddressContext(ctx context.Context, assetId string, ownerName string, destinationAddressDescription string, purpose string) (*CryptoAddress, error)
+}
+
+// CollectionService is the set of collection operations. It is implemented by Collection.
+type CollectionService interface {
+	Fees(request CollectionFeesRequest) (*CollectionFees, error)
+	FeesContext(ctx context.Context, request CollectionFeesRequest) (*CollectionFees, error)
+	RequestOTP(phone string) (*CollectionOTP, error)
+	RequestOTPContext(ctx context.Context, phone string) (*CollectionOTP, error)
+	VerifyOTP(pinId string, pin string) error
+	VerifyOTPContext(ctx context.Context, pinId string, pin string) error
+	Momo(request MomoCollectionRequest) (*CollectionTransaction, error)
+	MomoContext(ctx context.Context, request MomoCollectionRequest) (*CollectionTransaction, error)
+	Status(transactionId string) (*CollectionTransaction, error)
+	StatusContext(ctx context.Context, transactionId string) (*CollectionTransaction, error)
+}
+
+// TransactionService is the set of operations on the transactions of every type. It is implemented by Transaction.
+type TransactionService interface {
+	List(query TransactionQuery) (*TransactionPage, error)
+	ListContext(ctx context.Context, query TransactionQuery) (*TransactionPage, error)
+}
+
+// AccountService is the set of account operations. It is implemented by Eversend.
+type AccountService interface {
+	AccountProfile() (map[string]interface{}, error)
+	AccountProfileContext(ctx context.Context) (map[string]interface{}, error)
+}
+
+var (
+	_ AccountService     = (*Eversend)(nil)
+	_ WalletService      = (*Wallet)(nil)
+	_ ExchangeService    = (*Exchange)(nil)
+	_ PayoutService      = (*Payout)(nil)
+	_ BeneficiaryService = (*Beneficiary)(nil)
+	_ CryptoService      = (*Crypto)(nil)
+	_ CollectionService  = (*Collection)(nil)
+	_ TransactionService = (*Transaction)(nil)
+)
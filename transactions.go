@@ -0,0 +1,88 @@
+package eversendSdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TransactionQuery holds the filters of Transaction.List. Zero fields are ignored.
+// The From and To dates are inclusive, only their day in UTC is used. The Type is e.g "payout", "exchange" or "collection".
+// The Page starts at 1 and the Limit is the number of transactions per page.
+type TransactionQuery struct {
+	From     time.Time
+	To       time.Time
+	Type     string
+	Currency string
+	Status   string
+	Page     int
+	Limit    int
+}
+
+// TransactionPage is a page of transactions returned by Transaction.List. Total is the number of transactions
+// matching the query across all the pages.
+type TransactionPage struct {
+	Transactions []map[string]interface{} `json:"transactions"`
+	Total        int                      `json:"total"`
+	Page         int                      `json:"page"`
+	Limit        int                      `json:"limit"`
+}
+
+// List function to get a page of the transactions of the account of every type, oldest first.
+// The query filters the transactions by date, type, currency and status.
+func (e *Transaction) List(query TransactionQuery) (*TransactionPage, error) {
+	return e.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but sends the requests with ctx.
+func (e *Transaction) ListContext(ctx context.Context, query TransactionQuery) (*TransactionPage, error) {
+	var responseData struct {
+		Data TransactionPage `json:"data"`
+	}
+
+	path := "transactions"
+
+	if values := query.values(); len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	if err := e.client().call(ctx, "transactions.list", http.MethodGet, path, nil, &responseData); err != nil {
+		return nil, err
+	}
+
+	if responseData.Data.Transactions == nil {
+		responseData.Data.Transactions = []map[string]interface{}{}
+	}
+
+	return &responseData.Data, nil
+}
+
+func (q TransactionQuery) values() url.Values {
+	values := url.Values{}
+
+	if !q.From.IsZero() {
+		values.Set("from", q.From.UTC().Format("2006-01-02"))
+	}
+
+	if !q.To.IsZero() {
+		values.Set("to", q.To.UTC().Format("2006-01-02"))
+	}
+
+	for name, value := range map[string]string{"type": q.Type, "currency": q.Currency, "status": q.Status} {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+
+	if q.Page > 0 {
+		values.Set("page", strconv.Itoa(q.Page))
+	}
+
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	return values
+}
@@ -0,0 +1,96 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType is the kind of a webhook event e.g "payout.completed".
+type EventType string
+
+const (
+	EventPayoutCompleted    EventType = "payout.completed"
+	EventPayoutFailed       EventType = "payout.failed"
+	EventCollectionReceived EventType = "collection.received"
+	EventCryptoDeposit      EventType = "crypto.deposit"
+)
+
+// Event is a webhook notification sent by Eversend.
+// Payload holds the decoded Data: a *PayoutEvent, *CollectionEvent or *CryptoDepositEvent depending on the Type.
+// It is nil for event types this package does not know about, in which case Data can be decoded by the caller.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"event"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+
+	Payload interface{} `json:"-"`
+}
+
+// PayoutEvent is the payload of the payout.completed and payout.failed events.
+type PayoutEvent struct {
+	TransactionID       string  `json:"transactionId"`
+	TransactionRef      string  `json:"transactionRef"`
+	Status              string  `json:"status"`
+	Type                string  `json:"type"`
+	SourceWallet        string  `json:"sourceWallet"`
+	Amount              float64 `json:"amount"`
+	Fees                float64 `json:"fees"`
+	DestinationCountry  string  `json:"destinationCountry"`
+	DestinationCurrency string  `json:"destinationCurrency"`
+	DestinationAmount   float64 `json:"destinationAmount"`
+	PhoneNumber         string  `json:"phoneNumber"`
+	BankAccountNumber   string  `json:"bankAccountNumber"`
+	Reason              string  `json:"reason"`
+}
+
+// CollectionEvent is the payload of the collection.received event.
+type CollectionEvent struct {
+	TransactionID  string  `json:"transactionId"`
+	TransactionRef string  `json:"transactionRef"`
+	Status         string  `json:"status"`
+	Phone          string  `json:"phone"`
+	Country        string  `json:"country"`
+	Currency       string  `json:"currency"`
+	Amount         float64 `json:"amount"`
+	Fees           float64 `json:"fees"`
+}
+
+// CryptoDepositEvent is the payload of the crypto.deposit event.
+type CryptoDepositEvent struct {
+	TransactionID string  `json:"transactionId"`
+	Status        string  `json:"status"`
+	Address       string  `json:"address"`
+	AssetID       string  `json:"assetId"`
+	Coin          string  `json:"coin"`
+	Chain         string  `json:"chain"`
+	Amount        float64 `json:"amount"`
+	TxHash        string  `json:"txHash"`
+	Confirmations int     `json:"confirmations"`
+}
+
+// decodePayload sets the Payload of the event from its Data.
+func (e *Event) decodePayload() error {
+	var payload interface{}
+
+	switch e.Type {
+	case EventPayoutCompleted, EventPayoutFailed:
+		payload = &PayoutEvent{}
+	case EventCollectionReceived:
+		payload = &CollectionEvent{}
+	case EventCryptoDeposit:
+		payload = &CryptoDepositEvent{}
+	default:
+		return nil
+	}
+
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, payload); err != nil {
+			return err
+		}
+	}
+
+	e.Payload = payload
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package webhooks
+
+import "github.com/cetric32/eversend_go_sdk/internal/jsonfile"
+
+// FileStore is an EventStore that keeps its records in a JSON file so that deduplication survives restarts.
+// The file is rewritten after every change, so it suits the volume of webhooks a single account receives. Records
+// older than the retention, see SetRetention, are pruned so the file only holds the recent events.
+// Only one process may use a file at a time.
+type FileStore struct {
+	*MemoryStore
+
+	path string
+}
+
+type fileStoreData struct {
+	Events       map[string]*EventRecord `json:"events"`
+	Transactions map[string]string       `json:"transactions"`
+}
+
+// NewFileStore function to open, or create, a file backed EventStore at path.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+	}
+
+	data := fileStoreData{}
+
+	if err := jsonfile.Read(path, &data); err != nil {
+		return nil, err
+	}
+
+	if data.Events != nil {
+		store.events = data.Events
+	}
+
+	if data.Transactions != nil {
+		store.transactions = data.Transactions
+	}
+
+	store.persist = store.write
+
+	return store, nil
+}
+
+// write saves the records to the file.
+func (s *FileStore) write() error {
+	return jsonfile.Write(s.path, fileStoreData{
+		Events:       s.events,
+		Transactions: s.transactions,
+	})
+}
@@ -0,0 +1,244 @@
+// Package webhooks receives Eversend webhook notifications.
+//
+// Handler is an http.Handler that verifies the signature and timestamp of every request,
+// decodes the event and dispatches it to the functions registered for its type.
+//
+//	handler := webhooks.NewHandler(os.Getenv("EVERSEND_WEBHOOK_SECRET"))
+//	handler.On(webhooks.EventPayoutCompleted, func(ctx context.Context, event *webhooks.Event) error {
+//		payout := event.Payload.(*webhooks.PayoutEvent)
+//		...
+//	})
+//	http.Handle("/eversend/webhooks", handler)
+//
+// Eversend may deliver an event more than once or out of order. Pass WithEventStore a MemoryStore, or a FileStore
+// to survive restarts, to process each event once and to never move a transaction out of a terminal status.
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTolerance is how far the webhook timestamp may be from the current time before the request is rejected as a replay.
+const DefaultTolerance = 5 * time.Minute
+
+// maxBodyBytes limits the size of a webhook request body.
+const maxBodyBytes = 1 << 20
+
+// HandlerFunc handles a verified webhook event. Returning an error makes the Handler respond with a 500 so that Eversend retries the delivery.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler verifies and dispatches Eversend webhook requests.
+type Handler struct {
+	secret    string
+	tolerance time.Duration
+	now       func() time.Time
+	store     EventStore
+
+	mutex    sync.RWMutex
+	handlers map[EventType][]HandlerFunc
+	fallback []HandlerFunc
+}
+
+// Option configures a Handler.
+type Option func(h *Handler)
+
+// WithTolerance sets how old or how far in the future a webhook timestamp may be. The default is DefaultTolerance.
+func WithTolerance(tolerance time.Duration) Option {
+	return func(h *Handler) {
+		h.tolerance = tolerance
+	}
+}
+
+// WithEventStore makes the Handler deduplicate events by ID and drop events that would move a transaction
+// out of a terminal status. Without a store every delivery is dispatched.
+func WithEventStore(store EventStore) Option {
+	return func(h *Handler) {
+		h.store = store
+	}
+}
+
+// NewHandler function to create a webhook Handler.
+// The secret is the webhook secret configured on your Eversend account and is used to verify the signature of every request.
+// NewHandler panics if the secret is empty, as anyone could then sign webhooks.
+func NewHandler(secret string, opts ...Option) *Handler {
+	if secret == "" {
+		panic("webhooks: NewHandler called with an empty secret")
+	}
+
+	h := &Handler{
+		secret:    secret,
+		tolerance: DefaultTolerance,
+		now:       time.Now,
+		handlers:  map[EventType][]HandlerFunc{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// On registers fn to be called for events of the given type. Several functions can be registered for the same type
+// and are called in the order they were registered.
+func (h *Handler) On(eventType EventType, fn HandlerFunc) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// OnUnhandled registers fn to be called for events that have no function registered for their type.
+func (h *Handler) OnUnhandled(fn HandlerFunc) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.fallback = append(h.fallback, fn)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, "could not read body")
+		return
+	}
+
+	if len(body) > maxBodyBytes {
+		writeResponse(w, http.StatusRequestEntityTooLarge, "body too large")
+		return
+	}
+
+	event, err := h.Parse(r.Header, body)
+
+	if err != nil {
+		status := http.StatusBadRequest
+
+		if errors.Is(err, ErrInvalidSignature) || errors.Is(err, ErrTimestampOutOfRange) || errors.Is(err, ErrMissingSignature) {
+			status = http.StatusUnauthorized
+		}
+
+		writeResponse(w, status, err.Error())
+		return
+	}
+
+	if h.store == nil {
+		if err := h.dispatch(r.Context(), event); err != nil {
+			writeResponse(w, http.StatusInternalServerError, "event handler failed")
+			return
+		}
+
+		writeResponse(w, http.StatusOK, "ok")
+		return
+	}
+
+	h.serveWithStore(w, r, event)
+}
+
+func (h *Handler) serveWithStore(w http.ResponseWriter, r *http.Request, event *Event) {
+	if event.ID == "" {
+		writeResponse(w, http.StatusBadRequest, "event id is missing")
+		return
+	}
+
+	result, err := h.store.Claim(r.Context(), event)
+
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, "event store failed")
+		return
+	}
+
+	switch result {
+	case ClaimDuplicate:
+		writeResponse(w, http.StatusOK, "duplicate event")
+		return
+	case ClaimStale:
+		writeResponse(w, http.StatusOK, "stale event ignored")
+		return
+	case ClaimInProgress:
+		// Eversend retries deliveries that are not acknowledged, by then the other delivery has finished.
+		writeResponse(w, http.StatusConflict, "event is being processed")
+		return
+	}
+
+	handlerErr := h.dispatch(r.Context(), event)
+
+	if err := h.store.Finish(r.Context(), event, handlerErr); err != nil {
+		writeResponse(w, http.StatusInternalServerError, "event store failed")
+		return
+	}
+
+	if handlerErr != nil {
+		writeResponse(w, http.StatusInternalServerError, "event handler failed")
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "ok")
+}
+
+// Parse verifies the signature and timestamp headers of a webhook request and decodes its body into an Event.
+// It is used by ServeHTTP and is useful when the request is received by something other than an http.Handler.
+func (h *Handler) Parse(header http.Header, body []byte) (*Event, error) {
+	err := verify(h.secret, header.Get(SignatureHeader), header.Get(TimestampHeader), body, h.now(), h.tolerance)
+
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Event{}
+
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	if event.Type == "" {
+		return nil, errors.New("webhooks: event type is missing")
+	}
+
+	if err := event.decodePayload(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (h *Handler) dispatch(ctx context.Context, event *Event) error {
+	h.mutex.RLock()
+	handlers := h.handlers[event.Type]
+
+	if len(handlers) == 0 {
+		handlers = h.fallback
+	}
+
+	h.mutex.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": message,
+	})
+}
@@ -0,0 +1,176 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_test"
+
+var testNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+const payoutBody = `{"id":"evt_1","event":"payout.completed","data":{"transactionId":"BE1","status":"completed","amount":1000}}`
+
+func newTestHandler(opts ...Option) (*Handler, *[]*Event) {
+	h := NewHandler(testSecret, opts...)
+	h.now = func() time.Time { return testNow }
+
+	received := &[]*Event{}
+
+	h.OnUnhandled(func(ctx context.Context, event *Event) error {
+		*received = append(*received, event)
+		return nil
+	})
+
+	return h, received
+}
+
+func webhookRequest(body string, header http.Header) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req
+}
+
+func signedHeader(secret string, timestamp time.Time, body string) http.Header {
+	return http.Header{
+		SignatureHeader: {Sign(secret, timestamp, []byte(body))},
+		TimestampHeader: {strconv.FormatInt(timestamp.Unix(), 10)},
+	}
+}
+
+func TestHandlerAcceptsSignedEvent(t *testing.T) {
+	h, received := newTestHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, webhookRequest(payoutBody, signedHeader(testSecret, testNow.Add(-time.Minute), payoutBody)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d %s, want 200", w.Code, w.Body)
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("got %d events, want 1", len(*received))
+	}
+
+	payout, ok := (*received)[0].Payload.(*PayoutEvent)
+
+	if !ok || payout.TransactionID != "BE1" || payout.Amount != 1000 {
+		t.Fatalf("got payload %+v", (*received)[0].Payload)
+	}
+}
+
+func TestHandlerRejectsUnverifiedRequests(t *testing.T) {
+	valid := signedHeader(testSecret, testNow, payoutBody)
+
+	for _, test := range []struct {
+		name   string
+		method string
+		body   string
+		header http.Header
+		want   int
+	}{
+		{"missing signature", http.MethodPost, payoutBody, http.Header{TimestampHeader: valid[TimestampHeader]}, http.StatusUnauthorized},
+		{"missing timestamp", http.MethodPost, payoutBody, http.Header{SignatureHeader: valid[SignatureHeader]}, http.StatusUnauthorized},
+		{"missing headers", http.MethodPost, payoutBody, http.Header{}, http.StatusUnauthorized},
+		{"wrong secret", http.MethodPost, payoutBody, signedHeader("other", testNow, payoutBody), http.StatusUnauthorized},
+		{"empty secret", http.MethodPost, payoutBody, signedHeader("", testNow, payoutBody), http.StatusUnauthorized},
+		{"tampered body", http.MethodPost, strings.Replace(payoutBody, "1000", "9000", 1), valid, http.StatusUnauthorized},
+		{"signature not hex", http.MethodPost, payoutBody, http.Header{
+			SignatureHeader: {"not-hex"}, TimestampHeader: valid[TimestampHeader]}, http.StatusUnauthorized},
+		{"timestamp not a number", http.MethodPost, payoutBody, http.Header{
+			SignatureHeader: valid[SignatureHeader], TimestampHeader: {"yesterday"}}, http.StatusUnauthorized},
+		// a captured request replayed after the tolerance
+		{"replayed", http.MethodPost, payoutBody, signedHeader(testSecret, testNow.Add(-DefaultTolerance-time.Second), payoutBody), http.StatusUnauthorized},
+		{"timestamp in the future", http.MethodPost, payoutBody, signedHeader(testSecret, testNow.Add(DefaultTolerance+time.Second), payoutBody), http.StatusUnauthorized},
+		// the timestamp is signed, so moving it forward breaks the signature
+		{"timestamp changed", http.MethodPost, payoutBody, http.Header{
+			SignatureHeader: signedHeader(testSecret, testNow.Add(-time.Hour), payoutBody)[SignatureHeader],
+			TimestampHeader: valid[TimestampHeader]}, http.StatusUnauthorized},
+		{"not a POST", http.MethodGet, "", valid, http.StatusMethodNotAllowed},
+		{"malformed JSON", http.MethodPost, "{", signedHeader(testSecret, testNow, "{"), http.StatusBadRequest},
+		{"missing event type", http.MethodPost, `{"id":"evt_1"}`, signedHeader(testSecret, testNow, `{"id":"evt_1"}`), http.StatusBadRequest},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			h, received := newTestHandler()
+
+			req := webhookRequest(test.body, test.header)
+			req.Method = test.method
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != test.want {
+				t.Fatalf("got %d %s, want %d", w.Code, w.Body, test.want)
+			}
+
+			if len(*received) != 0 {
+				t.Fatalf("dispatched %d events", len(*received))
+			}
+		})
+	}
+}
+
+func TestHandlerTolerance(t *testing.T) {
+	h, received := newTestHandler(WithTolerance(time.Minute))
+
+	for _, test := range []struct {
+		age  time.Duration
+		want int
+	}{
+		{time.Minute, http.StatusOK},
+		{-time.Minute, http.StatusOK},
+		{time.Minute + time.Second, http.StatusUnauthorized},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, webhookRequest(payoutBody, signedHeader(testSecret, testNow.Add(-test.age), payoutBody)))
+
+		if w.Code != test.want {
+			t.Errorf("age %s: got %d, want %d", test.age, w.Code, test.want)
+		}
+	}
+
+	if len(*received) != 2 {
+		t.Fatalf("got %d events, want 2", len(*received))
+	}
+}
+
+func TestHandlerRejectsLargeBody(t *testing.T) {
+	h, _ := newTestHandler()
+	body := strings.Repeat("x", maxBodyBytes+1)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, webhookRequest(body, signedHeader(testSecret, testNow, body)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("got %d, want 413", w.Code)
+	}
+}
+
+func TestNewHandlerPanicsWithoutSecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewHandler accepted an empty secret")
+		}
+	}()
+
+	NewHandler("")
+}
+
+func TestVerifyRejectsEmptySecret(t *testing.T) {
+	header := signedHeader("", testNow, payoutBody)
+
+	err := verify("", header.Get(SignatureHeader), header.Get(TimestampHeader), []byte(payoutBody), testNow, DefaultTolerance)
+
+	if err != ErrInvalidSignature {
+		t.Fatalf("got %v, want ErrInvalidSignature", err)
+	}
+}
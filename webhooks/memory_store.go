@@ -0,0 +1,241 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLease is how long an event may stay in the processing state before another delivery may claim it again.
+// It lets an event be retried after the process handling it crashed.
+const DefaultLease = 5 * time.Minute
+
+// DefaultRetention is how long the record of an event is kept after its last change. Eversend stops redelivering
+// an event long before, so a redelivery is still recognised as a duplicate.
+const DefaultRetention = 7 * 24 * time.Hour
+
+// MemoryStore is an EventStore that keeps its records in memory. Records are lost when the process exits.
+type MemoryStore struct {
+	mutex        sync.Mutex
+	lease        time.Duration
+	retention    time.Duration
+	now          func() time.Time
+	events       map[string]*EventRecord
+	transactions map[string]string
+
+	// persist is called with the mutex held after every change. It is used by FileStore.
+	persist func() error
+}
+
+// NewMemoryStore function to create an in-memory EventStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		lease:        DefaultLease,
+		retention:    DefaultRetention,
+		now:          time.Now,
+		events:       map[string]*EventRecord{},
+		transactions: map[string]string{},
+	}
+}
+
+// SetLease changes how long an event may stay in the processing state before it can be claimed again.
+func (s *MemoryStore) SetLease(lease time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lease = lease
+}
+
+// SetRetention changes how long the record of an event is kept after its last change. Older records are pruned,
+// together with the status of their transaction once it has no records left, so the store does not grow forever.
+// A zero retention keeps every record.
+func (s *MemoryStore) SetRetention(retention time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.retention = retention
+}
+
+// Claim implements EventStore.
+func (s *MemoryStore) Claim(ctx context.Context, event *Event) (ClaimResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	record := s.events[event.ID]
+
+	if record != nil {
+		switch record.State {
+		case StateProcessed, StateIgnored:
+			return ClaimDuplicate, nil
+		case StateProcessing:
+			if s.leased(record, now) {
+				return ClaimInProgress, nil
+			}
+		}
+	}
+
+	transactionID, status := event.Transaction()
+
+	if transactionID != "" {
+		for _, other := range s.events {
+			if other.ID != event.ID && other.TransactionID == transactionID && s.leased(other, now) {
+				return ClaimInProgress, nil
+			}
+		}
+	}
+
+	previous := copyRecord(record)
+
+	if record == nil {
+		record = &EventRecord{
+			ID:            event.ID,
+			Type:          event.Type,
+			TransactionID: transactionID,
+			Status:        status,
+		}
+		s.events[event.ID] = record
+	}
+
+	record.UpdatedAt = now
+	result := ClaimAccepted
+
+	if current := s.transactions[transactionID]; transactionID != "" && IsTerminalStatus(current) {
+		// the same terminal status is an outcome already processed, another one would move the transaction
+		record.State = StateIgnored
+		result = ClaimStale
+
+		if strings.EqualFold(status, current) {
+			result = ClaimDuplicate
+		}
+	} else {
+		record.State = StateProcessing
+		record.Attempts++
+	}
+
+	if err := s.save(); err != nil {
+		// without the record saved the event must stay claimable by the next delivery
+		s.restore(event.ID, previous)
+
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// Finish implements EventStore.
+func (s *MemoryStore) Finish(ctx context.Context, event *Event, handlerErr error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.events[event.ID]
+
+	if record == nil || record.State != StateProcessing {
+		return errors.New("webhooks: event " + event.ID + " was not claimed")
+	}
+
+	record.UpdatedAt = s.now()
+
+	if handlerErr != nil {
+		record.State = StateFailed
+		record.LastError = handlerErr.Error()
+	} else {
+		record.State = StateProcessed
+		record.LastError = ""
+
+		if record.TransactionID != "" && record.Status != "" && !IsTerminalStatus(s.transactions[record.TransactionID]) {
+			s.transactions[record.TransactionID] = record.Status
+		}
+	}
+
+	// the outcome is kept in memory even if it cannot be saved: the event was dispatched, so a redelivery must not
+	// dispatch it again, and the next successful save persists it
+	return s.save()
+}
+
+// State implements EventStore.
+func (s *MemoryStore) State(ctx context.Context, eventID string) (*EventRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.events[eventID]
+
+	if record == nil {
+		return nil, ErrEventNotFound
+	}
+
+	copied := *record
+
+	return &copied, nil
+}
+
+// TransactionStatus implements EventStore.
+func (s *MemoryStore) TransactionStatus(ctx context.Context, transactionID string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.transactions[transactionID], nil
+}
+
+// leased reports whether the record is being processed and its lease has not expired.
+func (s *MemoryStore) leased(record *EventRecord, now time.Time) bool {
+	return record.State == StateProcessing && now.Sub(record.UpdatedAt) < s.lease
+}
+
+// save prunes the expired records, then persists the store.
+func (s *MemoryStore) save() error {
+	s.prune(s.now())
+
+	if s.persist == nil {
+		return nil
+	}
+
+	return s.persist()
+}
+
+// prune drops the records not changed within the retention, except those being processed, and the status of the
+// transactions left without records.
+func (s *MemoryStore) prune(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+
+	kept := map[string]bool{}
+
+	for id, record := range s.events {
+		if now.Sub(record.UpdatedAt) > s.retention && !s.leased(record, now) {
+			delete(s.events, id)
+			continue
+		}
+
+		kept[record.TransactionID] = true
+	}
+
+	for transactionID := range s.transactions {
+		if !kept[transactionID] {
+			delete(s.transactions, transactionID)
+		}
+	}
+}
+
+// restore puts back the record of an event as it was before a change that could not be saved.
+func (s *MemoryStore) restore(eventID string, previous *EventRecord) {
+	if previous == nil {
+		delete(s.events, eventID)
+		return
+	}
+
+	s.events[eventID] = previous
+}
+
+func copyRecord(record *EventRecord) *EventRecord {
+	if record == nil {
+		return nil
+	}
+
+	copied := *record
+
+	return &copied
+}
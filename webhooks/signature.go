@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 signature of the webhook request.
+	SignatureHeader = "X-Eversend-Signature"
+	// TimestampHeader holds the unix time in seconds at which the webhook request was signed.
+	TimestampHeader = "X-Eversend-Timestamp"
+)
+
+var (
+	ErrMissingSignature    = errors.New("webhooks: signature or timestamp header is missing")
+	ErrInvalidSignature    = errors.New("webhooks: signature does not match")
+	ErrTimestampOutOfRange = errors.New("webhooks: timestamp is outside the allowed tolerance")
+)
+
+// Sign function to compute the signature of a webhook body.
+// The signature is the hex encoded HMAC-SHA256, keyed with the secret, of the timestamp, a "." and the body.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return sign(secret, strconv.FormatInt(timestamp.Unix(), 10), body)
+}
+
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verify(secret string, signature string, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+
+	// an HMAC keyed with an empty secret can be computed by anyone
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return ErrTimestampOutOfRange
+	}
+
+	age := now.Sub(time.Unix(seconds, 0))
+
+	if age > tolerance || age < -tolerance {
+		return ErrTimestampOutOfRange
+	}
+
+	expected, err := hex.DecodeString(sign(secret, timestamp, body))
+
+	if err != nil {
+		return err
+	}
+
+	actual, err := hex.DecodeString(signature)
+
+	if err != nil || !hmac.Equal(expected, actual) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
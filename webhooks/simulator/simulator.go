@@ -0,0 +1,206 @@
+// Package simulator sends correctly signed sample Eversend webhooks to a local URL,
+// so webhook handlers can be exercised without a live Eversend account.
+//
+//	sim := simulator.New("http://localhost:8080/eversend/webhooks", secret)
+//	statusCode, body, err := sim.SendSample(ctx, webhooks.EventPayoutCompleted)
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cetric32/eversend_go_sdk/webhooks"
+)
+
+// EventTypes are the event types Sample can build.
+var EventTypes = []webhooks.EventType{
+	webhooks.EventPayoutCompleted,
+	webhooks.EventPayoutFailed,
+	webhooks.EventCollectionReceived,
+	webhooks.EventCryptoDeposit,
+}
+
+// Simulator posts signed webhook events to a URL.
+type Simulator struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+// New function to create a Simulator that posts to url and signs with secret.
+func New(url string, secret string) *Simulator {
+	return &Simulator{
+		URL:    url,
+		Secret: secret,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Sample function to build a sample event of the given type with a new event ID and transaction ID.
+// The Payload can be changed before the event is sent.
+func Sample(eventType webhooks.EventType) (*webhooks.Event, error) {
+	transactionID := "BE" + randomHex(8)
+
+	var payload interface{}
+
+	switch eventType {
+	case webhooks.EventPayoutCompleted:
+		payload = &webhooks.PayoutEvent{
+			TransactionID:       transactionID,
+			TransactionRef:      "ref-" + randomHex(4),
+			Status:              "completed",
+			Type:                "momo",
+			SourceWallet:        "UGX",
+			Amount:              50000,
+			Fees:                500,
+			DestinationCountry:  "KE",
+			DestinationCurrency: "KES",
+			DestinationAmount:   1750,
+			PhoneNumber:         "+254712345678",
+		}
+	case webhooks.EventPayoutFailed:
+		payload = &webhooks.PayoutEvent{
+			TransactionID:       transactionID,
+			TransactionRef:      "ref-" + randomHex(4),
+			Status:              "failed",
+			Type:                "bank",
+			SourceWallet:        "UGX",
+			Amount:              150000,
+			Fees:                1500,
+			DestinationCountry:  "UG",
+			DestinationCurrency: "UGX",
+			DestinationAmount:   150000,
+			BankAccountNumber:   "0123456789",
+			Reason:              "Invalid account number",
+		}
+	case webhooks.EventCollectionReceived:
+		payload = &webhooks.CollectionEvent{
+			TransactionID:  transactionID,
+			TransactionRef: "ref-" + randomHex(4),
+			Status:         "successful",
+			Phone:          "+256712345678",
+			Country:        "UG",
+			Currency:       "UGX",
+			Amount:         20000,
+			Fees:           300,
+		}
+	case webhooks.EventCryptoDeposit:
+		payload = &webhooks.CryptoDepositEvent{
+			TransactionID: transactionID,
+			Status:        "completed",
+			Address:       "TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7",
+			AssetID:       "USDT_TRON",
+			Coin:          "USDT",
+			Chain:         "TRON",
+			Amount:        25.5,
+			TxHash:        randomHex(32),
+			Confirmations: 20,
+		}
+	default:
+		return nil, fmt.Errorf("simulator: unknown event type %q", eventType)
+	}
+
+	return &webhooks.Event{
+		ID:        "evt_" + randomHex(12),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Payload:   payload,
+	}, nil
+}
+
+// NewRequest function to build a signed webhook request for the event.
+// When the event has a Payload it replaces the Data of the event.
+func NewRequest(ctx context.Context, url string, secret string, event *webhooks.Event) (*http.Request, error) {
+	body, err := Marshal(event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(secret, timestamp, body))
+
+	return req, nil
+}
+
+// Marshal function to encode the event the way Eversend sends it.
+func Marshal(event *webhooks.Event) ([]byte, error) {
+	if event.Payload != nil {
+		data, err := json.Marshal(event.Payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		copied := *event
+		copied.Data = data
+		event = &copied
+	}
+
+	return json.Marshal(event)
+}
+
+// Send posts the event and returns the status code and body of the response.
+func (s *Simulator) Send(ctx context.Context, event *webhooks.Event) (int, []byte, error) {
+	req, err := NewRequest(ctx, s.URL, s.Secret, event)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	client := s.Client
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+
+	return resp.StatusCode, body, err
+}
+
+// SendSample builds a sample event of the given type and posts it.
+func (s *Simulator) SendSample(ctx context.Context, eventType webhooks.EventType) (int, []byte, error) {
+	event, err := Sample(eventType)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return s.Send(ctx, event)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
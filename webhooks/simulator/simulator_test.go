@@ -0,0 +1,113 @@
+package simulator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cetric32/eversend_go_sdk/webhooks"
+)
+
+func TestSendSampleIsAcceptedByHandler(t *testing.T) {
+	handler := webhooks.NewHandler("whsec_test")
+
+	var mutex sync.Mutex
+	received := map[webhooks.EventType]*webhooks.Event{}
+
+	handler.OnUnhandled(func(ctx context.Context, event *webhooks.Event) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		received[event.Type] = event
+		return nil
+	})
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	sim := New(srv.URL, "whsec_test")
+
+	for _, eventType := range EventTypes {
+		statusCode, body, err := sim.SendSample(context.Background(), eventType)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if statusCode != http.StatusOK {
+			t.Fatalf("%s: got %d %s", eventType, statusCode, body)
+		}
+	}
+
+	if len(received) != len(EventTypes) {
+		t.Fatalf("got %d event types, want %d", len(received), len(EventTypes))
+	}
+
+	deposit, ok := received[webhooks.EventCryptoDeposit].Payload.(*webhooks.CryptoDepositEvent)
+
+	if !ok || deposit.AssetID != "USDT_TRON" || deposit.TransactionID == "" {
+		t.Fatalf("got payload %+v", received[webhooks.EventCryptoDeposit].Payload)
+	}
+}
+
+func TestSendWithWrongSecretIsRejected(t *testing.T) {
+	srv := httptest.NewServer(webhooks.NewHandler("whsec_test"))
+	t.Cleanup(srv.Close)
+
+	statusCode, _, err := New(srv.URL, "whsec_other").SendSample(context.Background(), webhooks.EventPayoutFailed)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("got %d, want 401", statusCode)
+	}
+}
+
+func TestSample(t *testing.T) {
+	first, err := Sample(webhooks.EventPayoutCompleted)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, _ := Sample(webhooks.EventPayoutCompleted)
+
+	if first.ID == second.ID || first.Payload.(*webhooks.PayoutEvent).TransactionID == second.Payload.(*webhooks.PayoutEvent).TransactionID {
+		t.Fatal("samples share their ids")
+	}
+
+	if _, err := Sample("payout.unknown"); err == nil {
+		t.Fatal("built a sample of an unknown type")
+	}
+}
+
+func TestMarshalUsesPayload(t *testing.T) {
+	event, _ := Sample(webhooks.EventPayoutCompleted)
+	event.Payload.(*webhooks.PayoutEvent).Amount = 123
+
+	req, err := NewRequest(context.Background(), "http://localhost/webhooks", "whsec_test", event)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := Marshal(event)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := webhooks.NewHandler("whsec_test").Parse(req.Header, body)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.ID != event.ID || parsed.Payload.(*webhooks.PayoutEvent).Amount != 123 {
+		t.Fatalf("got %+v", parsed.Payload)
+	}
+}
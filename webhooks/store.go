@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// EventState is the processing state of a webhook event recorded in an EventStore.
+type EventState string
+
+const (
+	StateProcessing EventState = "processing"
+	StateProcessed  EventState = "processed"
+	StateFailed     EventState = "failed"
+	// StateIgnored is recorded for events not dispatched because their transaction already has a terminal status.
+	StateIgnored EventState = "ignored"
+)
+
+// ClaimResult tells the Handler whether a claimed event should be dispatched.
+type ClaimResult int
+
+const (
+	// ClaimAccepted means the event is new, or failed before, and should be dispatched.
+	ClaimAccepted ClaimResult = iota
+	// ClaimDuplicate means the event was already processed or ignored, or is another event with the terminal status
+	// the transaction already has.
+	ClaimDuplicate
+	// ClaimInProgress means the event, or another event for the same transaction, is being processed.
+	ClaimInProgress
+	// ClaimStale means the transaction already has a terminal status and the event has another status, so the event
+	// must not be dispatched.
+	ClaimStale
+)
+
+// ErrEventNotFound is returned by EventStore.State for events the store has not seen.
+var ErrEventNotFound = errors.New("webhooks: event not found")
+
+// EventRecord is what an EventStore knows about an event.
+type EventRecord struct {
+	ID            string     `json:"id"`
+	Type          EventType  `json:"type"`
+	TransactionID string     `json:"transactionId,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	State         EventState `json:"state"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"lastError,omitempty"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// EventStore deduplicates webhook events and orders the events of a transaction.
+// Implementations must make Claim atomic: of several concurrent claims for the same event, or for events of the same
+// transaction, at most one may be accepted until it is finished.
+type EventStore interface {
+	// Claim records that the event is about to be processed.
+	Claim(ctx context.Context, event *Event) (ClaimResult, error)
+	// Finish records the outcome of processing a claimed event. A nil handlerErr marks the event processed and
+	// records its status as the status of its transaction.
+	Finish(ctx context.Context, event *Event, handlerErr error) error
+	// State returns the record of an event or ErrEventNotFound.
+	State(ctx context.Context, eventID string) (*EventRecord, error)
+	// TransactionStatus returns the last processed status of a transaction, or "" if none was processed.
+	TransactionStatus(ctx context.Context, transactionID string) (string, error)
+}
+
+// terminalStatuses are the transaction statuses that never change once reached.
+var terminalStatuses = map[string]bool{
+	"successful": true,
+	"success":    true,
+	"completed":  true,
+	"failed":     true,
+	"cancelled":  true,
+	"canceled":   true,
+	"reversed":   true,
+}
+
+// IsTerminalStatus reports whether a transaction status is final.
+func IsTerminalStatus(status string) bool {
+	return terminalStatuses[strings.ToLower(status)]
+}
+
+// Transaction returns the transaction id and status carried by the event payload, if any.
+func (e *Event) Transaction() (transactionID string, status string) {
+	switch payload := e.Payload.(type) {
+	case *PayoutEvent:
+		return payload.TransactionID, payload.Status
+	case *CollectionEvent:
+		return payload.TransactionID, payload.Status
+	case *CryptoDepositEvent:
+		return payload.TransactionID, payload.Status
+	}
+
+	return "", ""
+}
@@ -0,0 +1,265 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func payoutEvent(id string, transactionID string, status string) *Event {
+	return &Event{
+		ID:      id,
+		Type:    EventPayoutCompleted,
+		Payload: &PayoutEvent{TransactionID: transactionID, Status: status},
+	}
+}
+
+func TestHandlerDeduplicatesDeliveries(t *testing.T) {
+	h, received := newTestHandler(WithEventStore(NewMemoryStore()))
+
+	for i, want := range []string{`{"message":"ok"}`, `{"message":"duplicate event"}`} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, webhookRequest(payoutBody, signedHeader(testSecret, testNow, payoutBody)))
+
+		if w.Code != http.StatusOK || w.Body.String() != want+"\n" {
+			t.Fatalf("delivery %d: got %d %s, want %s", i+1, w.Code, w.Body, want)
+		}
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("dispatched %d times, want once", len(*received))
+	}
+}
+
+func TestMemoryStoreConcurrentClaims(t *testing.T) {
+	store := NewMemoryStore()
+	results := make([]ClaimResult, 20)
+
+	var wg sync.WaitGroup
+
+	for i := range results {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			// the same event, and other events of the same transaction
+			result, err := store.Claim(context.Background(), payoutEvent("evt_"+string(rune('a'+i%2)), "BE1", "completed"))
+
+			if err != nil {
+				t.Error(err)
+			}
+
+			results[i] = result
+		}(i)
+	}
+
+	wg.Wait()
+
+	accepted := 0
+
+	for _, result := range results {
+		if result == ClaimAccepted {
+			accepted++
+		} else if result != ClaimInProgress {
+			t.Fatalf("got claim result %d", result)
+		}
+	}
+
+	if accepted != 1 {
+		t.Fatalf("%d claims accepted, want 1", accepted)
+	}
+}
+
+func TestMemoryStoreTerminalStatus(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	completed := payoutEvent("evt_1", "BE1", "completed")
+
+	if result, err := store.Claim(ctx, completed); err != nil || result != ClaimAccepted {
+		t.Fatalf("got %d, %v", result, err)
+	}
+
+	if err := store.Finish(ctx, completed, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a late pending event must not move the payout back
+	if result, err := store.Claim(ctx, payoutEvent("evt_0", "BE1", "pending")); err != nil || result != ClaimStale {
+		t.Fatalf("got %d, %v, want ClaimStale", result, err)
+	}
+
+	if status, _ := store.TransactionStatus(ctx, "BE1"); status != "completed" {
+		t.Fatalf("got status %q", status)
+	}
+
+	record, err := store.State(ctx, "evt_0")
+
+	if err != nil || record.State != StateIgnored {
+		t.Fatalf("got %+v, %v", record, err)
+	}
+
+	// a conflicting terminal status is stale too
+	if result, err := store.Claim(ctx, payoutEvent("evt_2", "BE1", "failed")); err != nil || result != ClaimStale {
+		t.Fatalf("got %d, %v, want ClaimStale", result, err)
+	}
+}
+
+func TestMemoryStoreSameTerminalStatus(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	completed := payoutEvent("evt_1", "BE1", "completed")
+	store.Claim(ctx, completed)
+
+	if err := store.Finish(ctx, completed, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same outcome delivered again under another event id is a duplicate, not a conflict
+	if result, err := store.Claim(ctx, payoutEvent("evt_2", "BE1", "Completed")); err != nil || result != ClaimDuplicate {
+		t.Fatalf("got %d, %v, want ClaimDuplicate", result, err)
+	}
+
+	record, err := store.State(ctx, "evt_2")
+
+	if err != nil || record.State != StateIgnored || record.Attempts != 0 {
+		t.Fatalf("got %+v, %v, want the event ignored without an attempt", record, err)
+	}
+
+	if status, _ := store.TransactionStatus(ctx, "BE1"); status != "completed" {
+		t.Fatalf("got status %q", status)
+	}
+}
+
+func TestMemoryStoreLease(t *testing.T) {
+	store := NewMemoryStore()
+	now := testNow
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	event := payoutEvent("evt_1", "BE1", "completed")
+
+	store.Claim(ctx, event)
+
+	if result, _ := store.Claim(ctx, event); result != ClaimInProgress {
+		t.Fatalf("got %d during the lease, want ClaimInProgress", result)
+	}
+
+	// the process handling the event crashed
+	now = now.Add(DefaultLease)
+
+	if result, _ := store.Claim(ctx, event); result != ClaimAccepted {
+		t.Fatalf("got %d after the lease, want ClaimAccepted", result)
+	}
+
+	if record, _ := store.State(ctx, "evt_1"); record.Attempts != 2 {
+		t.Fatalf("got %d attempts, want 2", record.Attempts)
+	}
+}
+
+func TestMemoryStoreFailedSave(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	event := payoutEvent("evt_1", "BE1", "completed")
+
+	store.persist = func() error { return errors.New("disk full") }
+
+	if _, err := store.Claim(ctx, event); err == nil {
+		t.Fatal("claim succeeded without being saved")
+	}
+
+	if _, err := store.State(ctx, "evt_1"); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("got %v, want the claim rolled back", err)
+	}
+
+	// the next delivery is not held back by a lease of the failed claim
+	store.persist = nil
+
+	if result, err := store.Claim(ctx, event); err != nil || result != ClaimAccepted {
+		t.Fatalf("got %d, %v, want ClaimAccepted", result, err)
+	}
+}
+
+func TestMemoryStoreRetention(t *testing.T) {
+	store := NewMemoryStore()
+	now := testNow
+	store.now = func() time.Time { return now }
+	store.SetRetention(time.Hour)
+	ctx := context.Background()
+
+	old := payoutEvent("evt_1", "BE1", "completed")
+	store.Claim(ctx, old)
+	store.Finish(ctx, old, nil)
+
+	now = now.Add(2 * time.Hour)
+
+	recent := payoutEvent("evt_2", "BE2", "completed")
+	store.Claim(ctx, recent)
+
+	if _, err := store.State(ctx, "evt_1"); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("got %v, want the old event pruned", err)
+	}
+
+	if status, _ := store.TransactionStatus(ctx, "BE1"); status != "" {
+		t.Fatalf("got status %q, want the old transaction pruned", status)
+	}
+
+	if record, err := store.State(ctx, "evt_2"); err != nil || record.State != StateProcessing {
+		t.Fatalf("got %+v, %v", record, err)
+	}
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	ctx := context.Background()
+	event := payoutEvent("evt_1", "BE1", "completed")
+
+	store, err := NewFileStore(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store.Claim(ctx, event)
+
+	if err := store.Finish(ctx, event, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileStore(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := reopened.Claim(ctx, event); err != nil || result != ClaimDuplicate {
+		t.Fatalf("got %d, %v, want ClaimDuplicate", result, err)
+	}
+
+	if result, _ := reopened.Claim(ctx, payoutEvent("evt_2", "BE1", "failed")); result != ClaimStale {
+		t.Fatalf("got %d, want ClaimStale", result)
+	}
+}
+
+func TestFileStoreFailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(filepath.Join(dir, "missing", "events.json"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Claim(context.Background(), payoutEvent("evt_1", "BE1", "completed")); err == nil {
+		t.Fatal("claim succeeded without being written")
+	}
+
+	if _, err := store.State(context.Background(), "evt_1"); !errors.Is(err, ErrEventNotFound) {
+		t.Fatalf("got %v, want the claim rolled back", err)
+	}
+}